@@ -0,0 +1,49 @@
+package experiments
+
+import (
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/runner"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/klog"
+)
+
+func TestNodeDrain(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BDD test")
+}
+
+// BDD for running node-drain experiment
+var _ = Describe("BDD of running node-drain experiment", func() {
+
+	Context("Check for node-drain experiment via SDK", func() {
+
+		It("Should run the node drain experiment via SDK", func(specCtx SpecContext) {
+
+			experimentsDetails := types.ExperimentDetails{}
+
+			//Fetching all the default ENV
+			By("[PreChaos]: Fetching all default ENVs")
+			klog.Infof("[PreReq]: Getting the ENVs for the %v experiment", "node-drain")
+			environment.GetENV(&experimentsDetails, "node-drain", "node-drain-engine")
+
+			// Setup, run, verdict and cleanup of the experiment, a cancelled job stops the chaos before the cleanup
+			ctx, stop := runner.NotifyContext(specCtx)
+			defer stop()
+
+			By("[Chaos]: Running the node-drain experiment")
+			result, err := runner.Run(ctx, workflow.NodeDrain, &experimentsDetails, runner.Options{
+				PostChaosChecks: []runner.Check{runner.NodeRecoveryCheck},
+			})
+			runner.ExitIfCancelled(result)
+			Expect(err).To(BeNil(), "Failed to run the node-drain experiment, due to {%v}", err)
+			Expect(result.Phase).To(Equal(result.ExpectedPhase), "Experiment Run phase should be %s, but got %s: %s", result.ExpectedPhase, result.Phase, result.Reason())
+			Expect(result.UnmetCriteria).To(BeEmpty(), "Pass criteria of the Experiment Run not met: %s", result.Reason())
+		}, GracePeriod(runner.CancelGracePeriod))
+	})
+})
@@ -0,0 +1,285 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/podlogs"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+)
+
+// workflowResource is the Argo Workflow CR, which is named after the experiment
+var workflowResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"}
+
+// Run is the failed run the artifacts are collected for
+type Run struct {
+	ExperimentName string
+	Manifest       string                       // Rendered workflow manifest of the experiment
+	Summary        string                       // Outcome of the run, like its error, verdict and timeline
+	Logs           map[podlogs.Container][]byte // Logs kept while the run was tracked, of the pods deleted since included
+}
+
+// bundle is the content of the tarball, along with the artifacts which couldn't be collected
+type bundle struct {
+	files  map[string][]byte
+	names  []string
+	errors []string
+}
+
+// add adds the file to the bundle
+func (b *bundle) add(name string, data []byte) {
+	if _, ok := b.files[name]; !ok {
+		b.names = append(b.names, name)
+	}
+	b.files[name] = data
+}
+
+// addYAML adds the object to the bundle as YAML
+func (b *bundle) addYAML(name string, obj interface{}) {
+	data, err := yamlChe.Marshal(obj)
+	if err != nil {
+		b.fail("marshal %s: %v", name, err)
+		return
+	}
+	b.add(name, data)
+}
+
+// fail records an artifact which couldn't be collected
+func (b *bundle) fail(format string, args ...interface{}) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...))
+}
+
+// Collect writes the artifacts of the failed run to a gzipped tarball in the directory and returns its path.
+// Every artifact is attempted, the ones which couldn't be collected are listed in the errors.txt of the tarball.
+// The cluster artifacts are skipped if the clients aren't set up.
+func Collect(clients environment.ClientSets, experimentsDetails *types.ExperimentDetails, dir string, run Run) (string, error) {
+	b := &bundle{files: map[string][]byte{}}
+	b.add("summary.txt", []byte(run.Summary))
+	if manifest, err := yamlChe.JSONToYAML([]byte(run.Manifest)); err == nil {
+		b.add("workflow-manifest.yaml", manifest)
+	} else {
+		b.add("workflow-manifest.json", []byte(run.Manifest))
+	}
+
+	if clients.KubeClient == nil || clients.LitmusClient == nil {
+		b.fail("cluster artifacts: no kubeconfig")
+	} else {
+		collectChaosObjects(b, clients, experimentsDetails.ChaosNamespace, run.ExperimentName)
+		collectWorkflow(b, clients, run.ExperimentName)
+		collectPodLogs(b, clients, run.ExperimentName, run.Logs)
+		collectEvents(b, clients, experimentsDetails.ChaosNamespace, experimentsDetails.AppNS, experimentsDetails.InfraNamespace)
+		collectTargetPods(b, clients, experimentsDetails.AppNS, experimentsDetails.AppLabel)
+	}
+	if len(b.errors) != 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+	}
+	return write(b, dir, run.ExperimentName)
+}
+
+// collectChaosObjects adds the ChaosResults and the ChaosEngines of the run. The results are looked up on their own,
+// since the workflow deletes the engines once it is done while the results are kept.
+func collectChaosObjects(b *bundle, clients environment.ClientSets, chaosNamespace, experimentName string) {
+	if chaosNamespace == "" {
+		chaosNamespace = metav1.NamespaceAll
+	}
+	// The results carry the labels of the experiment pod, the workflow_name of its engine included
+	results, err := clients.LitmusClient.ChaosResults(chaosNamespace).List(metav1.ListOptions{
+		LabelSelector: "workflow_name=" + experimentName,
+	})
+	if err != nil {
+		b.fail("chaos results: %v", err)
+	} else {
+		for _, result := range results.Items {
+			b.addYAML(fmt.Sprintf("chaosresults/%s_%s.yaml", result.Namespace, result.Name), result)
+		}
+	}
+
+	engines, err := clients.LitmusClient.ChaosEngines(metav1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: "workflow_name=" + experimentName,
+	})
+	if err != nil {
+		b.fail("chaos engines: %v", err)
+		return
+	}
+	if len(engines.Items) == 0 {
+		b.fail("chaos engines: none found, they may have been cleaned up by the workflow")
+	}
+	for _, engine := range engines.Items {
+		b.addYAML(fmt.Sprintf("chaosengines/%s_%s.yaml", engine.Namespace, engine.Name), engine)
+
+		// The results of the runners which don't label them with the workflow are found through their engine
+		results, err := clients.LitmusClient.ChaosResults(engine.Namespace).List(metav1.ListOptions{
+			LabelSelector: "chaosUID=" + string(engine.UID),
+		})
+		if err != nil {
+			b.fail("chaos results of %s: %v", engine.Name, err)
+			continue
+		}
+		for _, result := range results.Items {
+			b.addYAML(fmt.Sprintf("chaosresults/%s_%s.yaml", result.Namespace, result.Name), result)
+		}
+	}
+}
+
+// collectWorkflow adds the Argo Workflow of the run
+func collectWorkflow(b *bundle, clients environment.ClientSets, experimentName string) {
+	if clients.DynamicClient == nil {
+		b.fail("argo workflow: no dynamic client")
+		return
+	}
+	workflows, err := clients.DynamicClient.Resource(workflowResource).Namespace(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: "metadata.name=" + experimentName,
+	})
+	if err != nil {
+		b.fail("argo workflow: %v", err)
+		return
+	}
+	if len(workflows.Items) == 0 {
+		b.fail("argo workflow: not found")
+	}
+	for _, workflow := range workflows.Items {
+		b.addYAML(fmt.Sprintf("workflow/%s_%s.yaml", workflow.GetNamespace(), workflow.GetName()), workflow.Object)
+	}
+}
+
+// collectPodLogs adds the logs of the containers of the workflow and chaos pods of the run. The logs kept while
+// the run was tracked come first, since the workflow deletes its pods once it completes, the pods still around
+// replace them with their full logs.
+func collectPodLogs(b *bundle, clients environment.ClientSets, experimentName string, keptLogs map[podlogs.Container][]byte) {
+	kept := make([]podlogs.Container, 0, len(keptLogs))
+	for container := range keptLogs {
+		kept = append(kept, container)
+	}
+	sort.Slice(kept, func(i, j int) bool { return logName(kept[i]) < logName(kept[j]) })
+	for _, container := range kept {
+		b.add(logName(container), keptLogs[container])
+	}
+
+	pods, err := podlogs.Pods(clients, experimentName)
+	if err != nil {
+		b.fail("pod logs: %v", err)
+		return
+	}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running == nil && status.State.Terminated == nil {
+				continue
+			}
+			container := podlogs.Container{Namespace: pod.Namespace, Pod: pod.Name, Name: status.Name}
+			logs, err := clients.KubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: status.Name}).Do().Raw()
+			if err != nil {
+				if _, ok := keptLogs[container]; !ok {
+					b.fail("logs of %s/%s: %v", pod.Name, status.Name, err)
+				}
+				continue
+			}
+			b.add(logName(container), logs)
+		}
+	}
+}
+
+// logName returns the name of the log file of the container in the bundle
+func logName(container podlogs.Container) string {
+	return fmt.Sprintf("logs/%s_%s_%s.log", container.Namespace, container.Pod, container.Name)
+}
+
+// collectEvents adds the Kubernetes events of the namespaces, oldest first
+func collectEvents(b *bundle, clients environment.ClientSets, namespaces ...string) {
+	seen := map[string]bool{}
+	for _, namespace := range namespaces {
+		if namespace == "" || seen[namespace] {
+			continue
+		}
+		seen[namespace] = true
+
+		events, err := clients.KubeClient.CoreV1().Events(namespace).List(metav1.ListOptions{})
+		if err != nil {
+			b.fail("events of %s: %v", namespace, err)
+			continue
+		}
+		sort.SliceStable(events.Items, func(i, j int) bool {
+			return events.Items[i].LastTimestamp.Before(&events.Items[j].LastTimestamp)
+		})
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tCOUNT\tMESSAGE")
+		for _, event := range events.Items {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%d\t%s\n", event.LastTimestamp.Format(time.RFC3339), event.Type, event.Reason,
+				strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, event.Count, event.Message)
+		}
+		w.Flush()
+		b.add(fmt.Sprintf("events/%s.txt", namespace), buf.Bytes())
+	}
+}
+
+// collectTargetPods adds the target pods of the fault, along with their status
+func collectTargetPods(b *bundle, clients environment.ClientSets, namespace, label string) {
+	if namespace == "" || label == "" {
+		return
+	}
+	pods, err := clients.KubeClient.CoreV1().Pods(namespace).List(metav1.ListOptions{LabelSelector: label})
+	if err != nil {
+		b.fail("target pods: %v", err)
+		return
+	}
+	for _, pod := range pods.Items {
+		b.addYAML(fmt.Sprintf("targets/%s_%s.yaml", pod.Namespace, pod.Name), pod)
+	}
+}
+
+// write writes the bundle to a gzipped tarball named after the experiment, its files under a directory of the same name
+func write(b *bundle, dir, experimentName string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create the artifacts directory: %v", err)
+	}
+	path := filepath.Join(dir, experimentName+".tar.gz")
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the artifacts tarball: %v", err)
+	}
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := time.Now()
+	for _, name := range b.names {
+		data := b.files[name]
+		header := &tar.Header{
+			Name:    experimentName + "/" + name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return "", fmt.Errorf("failed to write %s to the artifacts tarball: %v", name, err)
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			return "", fmt.Errorf("failed to write %s to the artifacts tarball: %v", name, err)
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to close the artifacts tarball: %v", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return "", fmt.Errorf("failed to close the artifacts tarball: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close the artifacts tarball: %v", err)
+	}
+	klog.Infof("[Artifacts]: Collected %d files of experiment %s to %s", len(b.names), experimentName, path)
+	return path, nil
+}
@@ -0,0 +1,118 @@
+package artifacts
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/podlogs"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	chaosClient "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/typed/litmuschaos/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// completedRunAPI serves a cluster where the workflow of the run completed: its engines and pods are deleted,
+// only the ChaosResult labelled with the workflow is left
+func completedRunAPI(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/apis/litmuschaos.io/v1alpha1/namespaces/litmus/chaosresults":
+			if r.URL.Query().Get("labelSelector") != "workflow_name=pod-delete-abc12" {
+				io.WriteString(w, `{"apiVersion":"litmuschaos.io/v1alpha1","kind":"ChaosResultList","items":[]}`)
+				return
+			}
+			io.WriteString(w, `{"apiVersion":"litmuschaos.io/v1alpha1","kind":"ChaosResultList","items":[{"metadata":{"name":"pod-delete-ce5x7k-pod-delete","namespace":"litmus","labels":{"workflow_name":"pod-delete-abc12"}},"status":{"experimentStatus":{"verdict":"Fail"}}}]}`)
+		case "/apis/litmuschaos.io/v1alpha1/chaosengines":
+			io.WriteString(w, `{"apiVersion":"litmuschaos.io/v1alpha1","kind":"ChaosEngineList","items":[]}`)
+		case "/api/v1/pods":
+			io.WriteString(w, `{"apiVersion":"v1","kind":"PodList","items":[]}`)
+		case "/api/v1/namespaces/litmus/events":
+			io.WriteString(w, `{"apiVersion":"v1","kind":"EventList","items":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// readTarball returns the files of the gzipped tarball by name
+func readTarball(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open the tarball: %v", err)
+	}
+	defer file.Close()
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("failed to read the tarball: %v", err)
+	}
+	files := map[string]string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("failed to read the tarball: %v", err)
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			t.Fatalf("failed to read %s from the tarball: %v", header.Name, err)
+		}
+		files[header.Name] = string(data)
+	}
+}
+
+func TestCollectAfterWorkflowCleanup(t *testing.T) {
+	srv := completedRunAPI(t)
+	config := &rest.Config{Host: srv.URL}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create the kube client: %v", err)
+	}
+	litmusClient, err := chaosClient.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create the litmus client: %v", err)
+	}
+	clients := environment.ClientSets{KubeClient: kubeClient, LitmusClient: litmusClient}
+
+	path, err := Collect(clients, &types.ExperimentDetails{ChaosNamespace: "litmus"}, t.TempDir(), Run{
+		ExperimentName: "pod-delete-abc12",
+		Manifest:       `{"kind":"Workflow"}`,
+		Summary:        "phase: Failed, expected Completed\n",
+		Logs: map[podlogs.Container][]byte{
+			{Namespace: "litmus", Pod: "pod-delete-ce5x7k-runner", Name: "chaos-runner"}: []byte("experiment job created\n"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Collect returned an error: %v", err)
+	}
+
+	files := readTarball(t, path)
+	if _, ok := files["pod-delete-abc12/chaosresults/litmus_pod-delete-ce5x7k-pod-delete.yaml"]; !ok {
+		t.Errorf("expected the chaos result of the deleted engine in the tarball, got %v", keys(files))
+	}
+	if logs := files["pod-delete-abc12/logs/litmus_pod-delete-ce5x7k-runner_chaos-runner.log"]; logs != "experiment job created\n" {
+		t.Errorf("expected the kept logs of the deleted runner pod in the tarball, got %q", logs)
+	}
+}
+
+// keys returns the names of the files
+func keys(files map[string]string) []string {
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,103 @@
+package chaoscenter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+)
+
+// Client calls the ChaosCenter GraphQL API for the operations which aren't covered by the SDK
+type Client struct {
+	Endpoint   string
+	Token      string
+	ProjectID  string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client which authenticates with the token of the SDK client
+func NewClient(experimentsDetails *types.ExperimentDetails, sdkClient sdk.Client) (*Client, error) {
+	token := sdkClient.Auth().GetToken()
+	if token == "" {
+		return nil, fmt.Errorf("failed to get authentication token from SDK client")
+	}
+	return &Client{
+		Endpoint:  strings.TrimSuffix(experimentsDetails.LitmusEndpoint, "/"),
+		Token:     token,
+		ProjectID: experimentsDetails.LitmusProjectID,
+		HTTPClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// graphqlResponse is the envelope of every GraphQL response
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// Do runs the GraphQL query or mutation and decodes its data into the given value
+func (c *Client) Do(operationName, query string, variables map[string]interface{}, data interface{}) error {
+	requestBody := map[string]interface{}{
+		"operationName": operationName,
+		"variables":     variables,
+		"query":         query,
+	}
+	jsonData, err := json.Marshal(requestBody)
+	if err != nil {
+		return fmt.Errorf("failed to marshal GraphQL request: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", c.Endpoint+"/api/query", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	req.Header.Set("Referer", c.Endpoint)
+	req.Header.Set("Origin", c.Endpoint)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "chaos-ci-lib/1.0")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make %s GraphQL request: %v", operationName, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("Error closing response body: %v\n", err)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s GraphQL request failed with status: %d", operationName, resp.StatusCode)
+	}
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %v", err)
+	}
+
+	var response graphqlResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return fmt.Errorf("failed to parse %s GraphQL response: %v", operationName, err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", response.Errors[0].Message)
+	}
+	if data == nil {
+		return nil
+	}
+	if err := json.Unmarshal(response.Data, data); err != nil {
+		return fmt.Errorf("failed to parse %s GraphQL data: %v", operationName, err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package chaoscenter
+
+import (
+	"fmt"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+const saveChaosExperimentMutation = `mutation saveChaosExperiment($projectID: ID!, $request: SaveChaosExperimentRequest!) {
+  saveChaosExperiment(projectID: $projectID, request: $request)
+}`
+
+const runChaosExperimentMutation = `mutation runChaosExperiment($projectID: ID!, $experimentID: String!) {
+  runChaosExperiment(projectID: $projectID, experimentID: $experimentID) {
+    notifyID
+  }
+}`
+
+const stopExperimentRunsMutation = `mutation stopExperimentRuns($projectID: ID!, $experimentID: String!, $experimentRunID: String) {
+  stopExperimentRuns(projectID: $projectID, experimentID: $experimentID, experimentRunID: $experimentRunID)
+}`
+
+// SaveExperiment saves the experiment without running it, the run is started by RunExperiment
+func (c *Client) SaveExperiment(request models.SaveChaosExperimentRequest) (string, error) {
+	var data struct {
+		SaveChaosExperiment string `json:"saveChaosExperiment"`
+	}
+	variables := map[string]interface{}{
+		"projectID": c.ProjectID,
+		"request":   request,
+	}
+	if err := c.Do("saveChaosExperiment", saveChaosExperimentMutation, variables, &data); err != nil {
+		return "", err
+	}
+	return data.SaveChaosExperiment, nil
+}
+
+// RunExperiment starts a run of the saved experiment and returns the notify ID of the run, which
+// identifies the run until the chaos infrastructure reports its run ID
+func (c *Client) RunExperiment(experimentID string) (string, error) {
+	var data struct {
+		RunChaosExperiment models.RunChaosExperimentResponse `json:"runChaosExperiment"`
+	}
+	variables := map[string]interface{}{
+		"projectID":    c.ProjectID,
+		"experimentID": experimentID,
+	}
+	if err := c.Do("runChaosExperiment", runChaosExperimentMutation, variables, &data); err != nil {
+		return "", err
+	}
+	// Cron experiments are run by their schedule, without a notify ID
+	if data.RunChaosExperiment.NotifyID == "" {
+		return "", fmt.Errorf("no notify ID returned for the run of experiment %s", experimentID)
+	}
+	return data.RunChaosExperiment.NotifyID, nil
+}
+
+// StopExperimentRun stops the run of the experiment, or every run of the experiment if no run ID is given
+func (c *Client) StopExperimentRun(experimentID, experimentRunID string) error {
+	var data struct {
+		StopExperimentRuns bool `json:"stopExperimentRuns"`
+	}
+	variables := map[string]interface{}{
+		"projectID":    c.ProjectID,
+		"experimentID": experimentID,
+	}
+	if experimentRunID != "" {
+		variables["experimentRunID"] = experimentRunID
+	}
+	if err := c.Do("stopExperimentRuns", stopExperimentRunsMutation, variables, &data); err != nil {
+		return err
+	}
+	if !data.StopExperimentRuns {
+		return fmt.Errorf("failed to stop the runs of experiment %s", experimentID)
+	}
+	return nil
+}
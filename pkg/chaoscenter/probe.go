@@ -0,0 +1,160 @@
+package chaoscenter
+
+import (
+	"fmt"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+const addProbeMutation = `mutation addProbe($request: ProbeRequest!, $projectID: ID!) {
+  addProbe(request: $request, projectID: $projectID) {
+    name
+    description
+    type
+    infrastructureType
+    tags
+  }
+}`
+
+// AddProbe creates the probe in the project of the client
+func (c *Client) AddProbe(request models.ProbeRequest) (*models.Probe, error) {
+	var data struct {
+		AddProbe models.Probe `json:"addProbe"`
+	}
+	variables := map[string]interface{}{
+		"request":   request,
+		"projectID": c.ProjectID,
+	}
+	if err := c.Do("addProbe", addProbeMutation, variables, &data); err != nil {
+		return nil, err
+	}
+	return &data.AddProbe, nil
+}
+
+const updateProbeMutation = `mutation updateProbe($request: ProbeRequest!, $projectID: ID!) {
+  updateProbe(request: $request, projectID: $projectID)
+}`
+
+// UpdateProbe replaces the properties of the probe with the same name
+func (c *Client) UpdateProbe(request models.ProbeRequest) error {
+	variables := map[string]interface{}{
+		"request":   request,
+		"projectID": c.ProjectID,
+	}
+	return c.Do("updateProbe", updateProbeMutation, variables, nil)
+}
+
+const deleteProbeMutation = `mutation deleteProbe($probeName: ID!, $projectID: ID!) {
+  deleteProbe(probeName: $probeName, projectID: $projectID)
+}`
+
+// DeleteProbe deletes the probe with the given name
+func (c *Client) DeleteProbe(probeName string) error {
+	var data struct {
+		DeleteProbe bool `json:"deleteProbe"`
+	}
+	variables := map[string]interface{}{
+		"probeName": probeName,
+		"projectID": c.ProjectID,
+	}
+	if err := c.Do("deleteProbe", deleteProbeMutation, variables, &data); err != nil {
+		return err
+	}
+	if !data.DeleteProbe {
+		return fmt.Errorf("failed to delete probe %s", probeName)
+	}
+	return nil
+}
+
+// runProperties are the fields shared by the properties of every probe type
+const runProperties = `probeTimeout
+      interval
+      retry
+      attempt
+      probePollingInterval
+      initialDelay
+      evaluationTimeout
+      stopOnFailure`
+
+const listProbesQuery = `query listProbes($projectID: ID!, $probeNames: [ID!]) {
+  listProbes(projectID: $projectID, probeNames: $probeNames) {
+    name
+    description
+    type
+    infrastructureType
+    tags
+    kubernetesHTTPProperties {
+      ` + runProperties + `
+      url
+      method {
+        get { criteria responseCode }
+        post { contentType body bodyPath criteria responseCode }
+      }
+      insecureSkipVerify
+    }
+    kubernetesCMDProperties {
+      ` + runProperties + `
+      command
+      comparator { type value criteria }
+      source
+    }
+    k8sProperties {
+      ` + runProperties + `
+      group
+      version
+      resource
+      namespace
+      resourceNames
+      fieldSelector
+      labelSelector
+      operation
+    }
+    promProperties {
+      ` + runProperties + `
+      endpoint
+      query
+      queryPath
+      comparator { type value criteria }
+    }
+  }
+}`
+
+// ListProbes returns the probes with the given names along with their properties,
+// or all the probes of the project if no name is given
+func (c *Client) ListProbes(probeNames []string) ([]*models.Probe, error) {
+	var data struct {
+		ListProbes []*models.Probe `json:"listProbes"`
+	}
+	variables := map[string]interface{}{
+		"projectID": c.ProjectID,
+	}
+	if len(probeNames) != 0 {
+		variables["probeNames"] = probeNames
+	}
+	if err := c.Do("listProbes", listProbesQuery, variables, &data); err != nil {
+		return nil, err
+	}
+
+	// The list can hold null entries
+	var probes []*models.Probe
+	for _, probe := range data.ListProbes {
+		if probe != nil {
+			probes = append(probes, probe)
+		}
+	}
+	return probes, nil
+}
+
+// ProbeExists checks if a probe with the given name exists in the project
+func (c *Client) ProbeExists(probeName string) (bool, error) {
+	probes, err := c.ListProbes([]string{probeName})
+	if err != nil {
+		return false, err
+	}
+	for _, probe := range probes {
+		if probe.Name == probeName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
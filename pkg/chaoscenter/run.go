@@ -0,0 +1,66 @@
+package chaoscenter
+
+import (
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+const getExperimentRunQuery = `query getExperimentRun($projectID: ID!, $experimentRunID: ID) {
+  getExperimentRun(projectID: $projectID, experimentRunID: $experimentRunID) {
+    experimentRunID
+    experimentID
+    experimentName
+    phase
+    resiliencyScore
+    faultsPassed
+    faultsFailed
+    faultsAwaited
+    faultsStopped
+    faultsNa
+    totalFaults
+    executionData
+    weightages { faultName weightage }
+    createdAt
+    updatedAt
+  }
+}`
+
+// GetExperimentRun returns the experiment run along with its execution data
+func (c *Client) GetExperimentRun(experimentRunID string) (*models.ExperimentRun, error) {
+	var data struct {
+		GetExperimentRun models.ExperimentRun `json:"getExperimentRun"`
+	}
+	variables := map[string]interface{}{
+		"projectID":       c.ProjectID,
+		"experimentRunID": experimentRunID,
+	}
+	if err := c.Do("getExperimentRun", getExperimentRunQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	return &data.GetExperimentRun, nil
+}
+
+const getExperimentRunByNotifyIDQuery = `query getExperimentRun($projectID: ID!, $notifyID: ID) {
+  getExperimentRun(projectID: $projectID, notifyID: $notifyID) {
+    experimentRunID
+    experimentID
+    notifyID
+    phase
+    executionData
+  }
+}`
+
+// GetExperimentRunByNotifyID returns the phase and the execution data of the run started with the notify ID.
+// Its run ID is empty until the chaos infrastructure picks the run up.
+func (c *Client) GetExperimentRunByNotifyID(notifyID string) (*models.ExperimentRun, error) {
+	var data struct {
+		GetExperimentRun models.ExperimentRun `json:"getExperimentRun"`
+	}
+	variables := map[string]interface{}{
+		"projectID": c.ProjectID,
+		"notifyID":  notifyID,
+	}
+	if err := c.Do("getExperimentRun", getExperimentRunByNotifyIDQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	return &data.GetExperimentRun, nil
+}
@@ -0,0 +1,133 @@
+package chaoshub
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// bundle holds the faults shipped with chaos-ci-lib, laid out like a ChaosHub checkout
+//
+//go:embed faults
+var bundle embed.FS
+
+// Hub resolves fault definitions from the faults/<category>/<name> directories of a ChaosHub
+type Hub struct {
+	fsys fs.FS
+}
+
+// Fault is the definition of a single fault in the hub
+type Fault struct {
+	Name       string
+	Category   string
+	Experiment []byte // Content of fault.yaml, the ChaosExperiment
+	Engine     []byte // Content of engine.yaml, the sample ChaosEngine
+}
+
+// New returns the hub rooted at the given ChaosHub checkout, or the bundled faults if the path is empty
+func New(hubPath string) *Hub {
+	if hubPath == "" {
+		return &Hub{fsys: bundle}
+	}
+	return &Hub{fsys: os.DirFS(hubPath)}
+}
+
+// Get returns the fault with the given name from any of the hub categories
+func (h *Hub) Get(name string) (*Fault, error) {
+	matches, err := fs.Glob(h.fsys, path.Join("faults", "*", name, "fault.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, errors.Errorf("fault %s not found in the chaoshub", name)
+	}
+
+	faultDir := path.Dir(matches[0])
+	experiment, err := fs.ReadFile(h.fsys, matches[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the definition of fault %s", name)
+	}
+	// The sample engine is optional, the fault can still be run without it
+	engine, err := fs.ReadFile(h.fsys, path.Join(faultDir, "engine.yaml"))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Wrapf(err, "failed to read the engine of fault %s", name)
+	}
+
+	return &Fault{
+		Name:       name,
+		Category:   path.Base(path.Dir(faultDir)),
+		Experiment: experiment,
+		Engine:     engine,
+	}, nil
+}
+
+// List returns the names of all the faults available in the hub
+func (h *Hub) List() ([]string, error) {
+	matches, err := fs.Glob(h.fsys, path.Join("faults", "*", "*", "fault.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, match := range matches {
+		names = append(names, path.Base(path.Dir(match)))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// EngineEnv returns the default env of the fault from the sample engine
+func (f *Fault) EngineEnv() ([]corev1.EnvVar, error) {
+	engine, err := f.engine()
+	if err != nil || engine == nil {
+		return nil, err
+	}
+
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	var env []corev1.EnvVar
+	for _, item := range experiments {
+		experiment, ok := item.(map[string]interface{})
+		if !ok || experiment["name"] != f.Name {
+			continue
+		}
+		envs, _, _ := unstructured.NestedSlice(experiment, "spec", "components", "env")
+		for _, e := range envs {
+			m, ok := e.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value, _ := m["value"].(string)
+			env = append(env, corev1.EnvVar{Name: fmt.Sprint(m["name"]), Value: value})
+		}
+	}
+	return env, nil
+}
+
+// TargetsApplication returns true if the sample engine of the fault targets an application using appinfo
+func (f *Fault) TargetsApplication() bool {
+	engine, err := f.engine()
+	if err != nil || engine == nil {
+		return false
+	}
+	_, found, _ := unstructured.NestedMap(engine, "spec", "appinfo")
+	return found
+}
+
+// engine parses the sample engine of the fault, nil if the fault has none
+func (f *Fault) engine() (map[string]interface{}, error) {
+	if len(f.Engine) == 0 {
+		return nil, nil
+	}
+	engine := map[string]interface{}{}
+	if err := yamlChe.Unmarshal(f.Engine, &engine); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the engine of fault %s", f.Name)
+	}
+	return engine, nil
+}
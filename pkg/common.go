@@ -20,7 +20,7 @@ func Kubectl(command ...string) error {
 	cmd := exec.Command("kubectl", command...)
 	cmd.Stdout = &out
 	cmd.Stderr = &stderr
-	err = cmd.Run()
+	err := cmd.Run()
 	if err != nil {
 		log.Infof("%s: %s", fmt.Sprint(err), stderr.String())
 		log.Infof("Error: %v", err)
@@ -3,6 +3,7 @@ package environment
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	types "github.com/litmuschaos/chaos-ci-lib/pkg/types"
 )
@@ -49,6 +50,10 @@ func GetENV(experimentDetails *types.ExperimentDetails, expName, engineName stri
 	experimentDetails.Replicas, _ = strconv.Atoi(Getenv("REPLICA_COUNT", "0"))
 	experimentDetails.ExperimentTimeout, _ = strconv.Atoi(Getenv("EXPERIMENT_TIMEOUT", "8"))
 	experimentDetails.ExperimentPollingInterval, _ = strconv.Atoi(Getenv("EXPERIMENT_POLLING_INTERVAL", "15"))
+	experimentDetails.TargetNode = Getenv("TARGET_NODE", "")
+	experimentDetails.NodeLabel = Getenv("NODE_LABEL", "")
+	experimentDetails.Taints = Getenv("TAINTS", "node.kubernetes.io/unreachable:NoExecute")
+	experimentDetails.NodeRecoveryTimeout, _ = strconv.Atoi(Getenv("NODE_RECOVERY_TIMEOUT", "300"))
 
 	//All Images for running chaos test
 	experimentDetails.GoExperimentImage = Getenv("EXPERIMENT_IMAGE", "litmuschaos/go-runner:ci")
@@ -99,6 +104,49 @@ func GetENV(experimentDetails *types.ExperimentDetails, expName, engineName stri
 	experimentDetails.ProbeInterval = Getenv("LITMUS_PROBE_INTERVAL", "10s")
 	experimentDetails.ProbeAttempts, _ = strconv.Atoi(Getenv("LITMUS_PROBE_ATTEMPTS", "1"))
 	experimentDetails.ProbeResponseCode = Getenv("LITMUS_PROBE_RESPONSE_CODE", "200")
+	experimentDetails.DeleteProbes, _ = strconv.ParseBool(Getenv("LITMUS_DELETE_PROBES", "false"))
+	experimentDetails.InlineProbePaths = SplitList(Getenv("LITMUS_INLINE_PROBES", ""))
+
+	// Probe definition
+	experimentDetails.ProbeDefinitionPath = Getenv("LITMUS_PROBE_DEFINITION", "")
+	experimentDetails.ProbeHTTPMethod = Getenv("LITMUS_PROBE_HTTP_METHOD", "GET")
+	experimentDetails.ProbeHTTPBody = Getenv("LITMUS_PROBE_HTTP_BODY", "")
+	experimentDetails.ProbeHTTPContentType = Getenv("LITMUS_PROBE_HTTP_CONTENT_TYPE", "application/json")
+	experimentDetails.ProbeCommand = Getenv("LITMUS_PROBE_COMMAND", "ls -l")
+	experimentDetails.ProbeSourceImage = Getenv("LITMUS_PROBE_SOURCE_IMAGE", "")
+	experimentDetails.ProbeComparatorType = Getenv("LITMUS_PROBE_COMPARATOR_TYPE", "")
+	experimentDetails.ProbeComparatorCriteria = Getenv("LITMUS_PROBE_COMPARATOR_CRITERIA", "")
+	experimentDetails.ProbeComparatorValue = Getenv("LITMUS_PROBE_COMPARATOR_VALUE", "")
+	experimentDetails.ProbeK8sGroup = Getenv("LITMUS_PROBE_K8S_GROUP", "")
+	experimentDetails.ProbeK8sVersion = Getenv("LITMUS_PROBE_K8S_VERSION", "v1")
+	experimentDetails.ProbeK8sResource = Getenv("LITMUS_PROBE_K8S_RESOURCE", "pods")
+	experimentDetails.ProbeK8sNamespace = Getenv("LITMUS_PROBE_K8S_NAMESPACE", "")
+	experimentDetails.ProbeK8sResourceNames = Getenv("LITMUS_PROBE_K8S_RESOURCE_NAMES", "")
+	experimentDetails.ProbeK8sFieldSelector = Getenv("LITMUS_PROBE_K8S_FIELD_SELECTOR", "")
+	experimentDetails.ProbeK8sLabelSelector = Getenv("LITMUS_PROBE_K8S_LABEL_SELECTOR", "")
+	experimentDetails.ProbeK8sOperation = Getenv("LITMUS_PROBE_K8S_OPERATION", "present")
+	experimentDetails.ProbePromEndpoint = Getenv("LITMUS_PROBE_PROM_ENDPOINT", "")
+	experimentDetails.ProbePromQuery = Getenv("LITMUS_PROBE_PROM_QUERY", "")
+
+	// Pass criteria
+	experimentDetails.MinResiliencyScore, _ = strconv.ParseFloat(Getenv("LITMUS_MIN_RESILIENCY_SCORE", "0"), 64)
+	experimentDetails.MinProbeSuccessPercentage, _ = strconv.ParseFloat(Getenv("LITMUS_MIN_PROBE_SUCCESS_PERCENTAGE", "0"), 64)
+	experimentDetails.MustPassProbes = SplitList(Getenv("LITMUS_MUST_PASS_PROBES", ""))
+
+	// Debugging
+	experimentDetails.TailLogs, _ = strconv.ParseBool(Getenv("LITMUS_TAIL_LOGS", "false"))
+	experimentDetails.ArtifactsDir = Getenv("LITMUS_ARTIFACTS_DIR", "")
+}
+
+// SplitList returns the non-empty items of a comma separated list, like the values of the list ENVs
+func SplitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // Getenv fetch the env and set the default value, if any
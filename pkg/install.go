@@ -6,6 +6,7 @@ import (
 	yamlChe "github.com/ghodss/yaml"
 	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
 	"github.com/litmuschaos/chaos-ci-lib/pkg/log"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
 	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
 	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
 	"github.com/pkg/errors"
@@ -27,8 +28,6 @@ import (
 	"k8s.io/client-go/restmapper"
 )
 
-var err error
-
 // CreateChaosResource creates litmus components with given inputs
 func CreateChaosResource(fileData []byte, namespace string, clients environment.ClientSets) error {
 
@@ -38,7 +37,7 @@ func CreateChaosResource(fileData []byte, namespace string, clients environment.
 	for {
 		//runtime defines conversions between generic types and structs to map query strings to struct objects.
 		var rawObj runtime.RawExtension
-		if err = decoder.Decode(&rawObj); err != nil {
+		if err := decoder.Decode(&rawObj); err != nil {
 			// if the object is null, successfully installed all manifest
 			if rawObj.Raw == nil {
 				return nil
@@ -96,7 +95,7 @@ func CreateChaosResource(fileData []byte, namespace string, clients environment.
 func InstallRbac(experimentsDetails *types.ExperimentDetails, rbacNamespace string) error {
 
 	//Fetch RBAC file
-	err = DownloadFile("/tmp/"+experimentsDetails.ExperimentName+"-sa.yaml", experimentsDetails.RbacPath)
+	err := DownloadFile("/tmp/"+experimentsDetails.ExperimentName+"-sa.yaml", experimentsDetails.RbacPath)
 	if err != nil {
 		return errors.Errorf("Fail to fetch the rbac file, due to %v", err)
 	}
@@ -110,7 +109,7 @@ func InstallRbac(experimentsDetails *types.ExperimentDetails, rbacNamespace stri
 	log.Info("[RBAC]: Installing RABC...")
 	//Creating rbac
 	command := []string{"apply", "-f", "/tmp/" + experimentsDetails.ExperimentName + "-sa.yaml", "-n", rbacNamespace}
-	err := Kubectl(command...)
+	err = Kubectl(command...)
 	if err != nil {
 		return errors.Errorf("fail to apply rbac file, err: %v", err)
 	}
@@ -196,6 +195,11 @@ func InstallChaosEngine(experimentsDetails *types.ExperimentDetails, chaosEngine
 		return errors.Errorf("Fail to marshal ChaosEngine %v", err)
 	}
 
+	// Add the inline probes, if any
+	if fileData, err = addInlineProbes(experimentsDetails, fileData); err != nil {
+		return err
+	}
+
 	//Creating chaos engine
 	log.Info("[Engine]: Installing ChaosEngine...")
 	if err = CreateChaosResource(fileData, experimentsDetails.ChaosNamespace, clients); err != nil {
@@ -205,6 +209,28 @@ func InstallChaosEngine(experimentsDetails *types.ExperimentDetails, chaosEngine
 	return nil
 }
 
+// addInlineProbes adds the probes of LITMUS_INLINE_PROBES to the experiments of the given ChaosEngine.
+// The probes are added to the unstructured engine, since the chaos-operator API in use expects integer run properties.
+func addInlineProbes(experimentsDetails *types.ExperimentDetails, engineData []byte) ([]byte, error) {
+	if len(experimentsDetails.InlineProbePaths) == 0 {
+		return engineData, nil
+	}
+	definitions, err := probe.LoadAll(experimentsDetails.InlineProbePaths)
+	if err != nil {
+		return nil, errors.Errorf("Fail to load the inline probes, due to %v", err)
+	}
+
+	engine := map[string]interface{}{}
+	if err := json.Unmarshal(engineData, &engine); err != nil {
+		return nil, errors.Errorf("Fail to unmarshal ChaosEngine %v", err)
+	}
+	if err := probe.SetEngineProbes(engine, definitions); err != nil {
+		return nil, errors.Errorf("Fail to add the inline probes to ChaosEngine, due to %v", err)
+	}
+	log.Infof("[Engine]: Added %d inline probes to ChaosEngine", len(definitions))
+	return json.Marshal(engine)
+}
+
 // InstallLitmus installs the latest version of litmus
 func InstallLitmus(testsDetails *types.ExperimentDetails) error {
 
@@ -217,7 +243,7 @@ func InstallLitmus(testsDetails *types.ExperimentDetails) error {
 		return errors.Errorf("Unable to update operator image, due to %v", err)
 
 	}
-	if err = EditKeyValue("/tmp/install-litmus.yaml", "  - chaos-operator", "imagePullPolicy: Always", "imagePullPolicy: "+testsDetails.ImagePullPolicy); err != nil {
+	if err := EditKeyValue("/tmp/install-litmus.yaml", "  - chaos-operator", "imagePullPolicy: Always", "imagePullPolicy: "+testsDetails.ImagePullPolicy); err != nil {
 		return errors.Errorf("Unable to update image pull policy, due to %v", err)
 	}
 	log.Info("Updating Chaos Runner Image ...")
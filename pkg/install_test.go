@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const checkAppProbe = `name: check-app
+type: httpProbe
+mode: Continuous
+runProperties:
+  probeTimeout: 5s
+  interval: 2s
+httpProbe/inputs:
+  url: http://app.default.svc:8080
+  method:
+    get:
+      criteria: ==
+      responseCode: "200"
+`
+
+func TestAddInlineProbes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "check-app.yaml")
+	if err := os.WriteFile(path, []byte(checkAppProbe), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	chaosEngine := v1alpha1.ChaosEngine{
+		Spec: v1alpha1.ChaosEngineSpec{
+			Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+		},
+	}
+	engineData, err := json.Marshal(chaosEngine)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileData, err := addInlineProbes(&types.ExperimentDetails{InlineProbePaths: []string{path}}, engineData)
+	if err != nil {
+		t.Fatalf("failed to add the inline probes: %v", err)
+	}
+	engine := map[string]interface{}{}
+	if err := json.Unmarshal(fileData, &engine); err != nil {
+		t.Fatalf("failed to parse the chaos engine: %v", err)
+	}
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	probes, _, _ := unstructured.NestedSlice(experiments[0].(map[string]interface{}), "spec", "probe")
+	if len(probes) != 1 {
+		t.Fatalf("expected the inline probe in the chaos engine, got %d probes", len(probes))
+	}
+
+	inlineProbe := probes[0].(map[string]interface{})
+	fields := map[string][]string{
+		"check-app":                   {"name"},
+		"Continuous":                  {"mode"},
+		"5s":                          {"runProperties", "probeTimeout"},
+		"http://app.default.svc:8080": {"httpProbe/inputs", "url"},
+		"200":                         {"httpProbe/inputs", "method", "get", "responseCode"},
+	}
+	for want, fieldPath := range fields {
+		if got, _, _ := unstructured.NestedString(inlineProbe, fieldPath...); got != want {
+			t.Errorf("expected %v of the inline probe to be %q, got %q", fieldPath, want, got)
+		}
+	}
+}
+
+func TestAddInlineProbesWithoutProbes(t *testing.T) {
+	engineData := []byte(`{"spec":{}}`)
+	fileData, err := addInlineProbes(&types.ExperimentDetails{}, engineData)
+	if err != nil || string(fileData) != string(engineData) {
+		t.Errorf("expected the chaos engine to be left as is, got %s and %v", fileData, err)
+	}
+}
@@ -0,0 +1,142 @@
+package pkg
+
+import (
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/log"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/litmus-go/pkg/utils/retry"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WaitForNodeRecovery waits for the target nodes of a node fault to be Ready, schedulable and free of
+// the injected taints. Without a target node, the nodes the ChaosResults of the run report as targeted are checked.
+func WaitForNodeRecovery(experimentsDetails *types.ExperimentDetails, clients environment.ClientSets) error {
+	delay := experimentsDetails.Delay
+	if delay <= 0 {
+		delay = 5
+	}
+	err := retry.
+		Times(uint(experimentsDetails.NodeRecoveryTimeout / delay)).
+		Wait(time.Duration(delay) * time.Second).
+		Try(func(attempt uint) error {
+			nodes, err := getTargetNodes(experimentsDetails, clients)
+			if err != nil {
+				return err
+			}
+			for _, node := range nodes {
+				if !isNodeReady(node) {
+					log.Infof("[Status]: Node %v is not yet Ready", node.Name)
+					return errors.Errorf("node %v is not ready", node.Name)
+				}
+				if node.Spec.Unschedulable {
+					log.Infof("[Status]: Node %v is still cordoned", node.Name)
+					return errors.Errorf("node %v is unschedulable", node.Name)
+				}
+				if taint := findInjectedTaint(node, experimentsDetails.Taints); taint != "" {
+					log.Infof("[Status]: Node %v still has the %v taint", node.Name, taint)
+					return errors.Errorf("node %v still has the %v taint", node.Name, taint)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return errors.Errorf("target nodes failed to recover, due to %v", err)
+	}
+	log.Info("[Status]: Target nodes are Ready and schedulable")
+
+	return nil
+}
+
+// GetApplicationNode returns the node of the first scheduled pod of the application
+func GetApplicationNode(appNamespace, appLabel string, clients environment.ClientSets) (string, error) {
+	podList, err := clients.KubeClient.CoreV1().Pods(appNamespace).List(metav1.ListOptions{LabelSelector: appLabel})
+	if err != nil {
+		return "", errors.Errorf("unable to list the application pods, due to %v", err)
+	}
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" {
+			return pod.Spec.NodeName, nil
+		}
+	}
+	return "", errors.Errorf("no scheduled pods found with the label %v in the %v namespace", appLabel, appNamespace)
+}
+
+// getTargetNodes returns the nodes named by the TargetNode of the experiment,
+// or the nodes targeted by the run if it is empty, like the node picked among the NodeLabel ones
+func getTargetNodes(experimentsDetails *types.ExperimentDetails, clients environment.ClientSets) ([]v1.Node, error) {
+	names := strings.Split(experimentsDetails.TargetNode, ",")
+	if experimentsDetails.TargetNode == "" {
+		var err error
+		if names, err = getChaosResultTargetNodes(experimentsDetails, clients); err != nil {
+			return nil, err
+		}
+	}
+
+	var nodes []v1.Node
+	for _, name := range names {
+		node, err := clients.KubeClient.CoreV1().Nodes().Get(strings.TrimSpace(name), metav1.GetOptions{})
+		if err != nil {
+			return nil, errors.Errorf("unable to get the node %v, due to %v", name, err)
+		}
+		nodes = append(nodes, *node)
+	}
+	return nodes, nil
+}
+
+// getChaosResultTargetNodes returns the nodes the ChaosResults of the run report as targeted
+func getChaosResultTargetNodes(experimentsDetails *types.ExperimentDetails, clients environment.ClientSets) ([]string, error) {
+	resultList, err := clients.LitmusClient.ChaosResults(experimentsDetails.ChaosNamespace).List(metav1.ListOptions{
+		LabelSelector: "workflow_name=" + experimentsDetails.ExperimentName,
+	})
+	if err != nil {
+		return nil, errors.Errorf("unable to list the chaos results, due to %v", err)
+	}
+	var names []string
+	for _, result := range resultList.Items {
+		for _, target := range result.Status.History.Targets {
+			if strings.EqualFold(target.Kind, "node") && target.Name != "" && !ContainsString(names, target.Name) {
+				names = append(names, target.Name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.Errorf("no target node set and none reported by the chaos results of %v", experimentsDetails.ExperimentName)
+	}
+	return names, nil
+}
+
+// isNodeReady returns true if the Ready condition of the node is true
+func isNodeReady(node v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// findInjectedTaint returns the first of the given taints, in the key[=value]:effect format, which is still on the node
+func findInjectedTaint(node v1.Node, taints string) string {
+	if taints == "" {
+		return ""
+	}
+	for _, taint := range strings.Split(taints, ",") {
+		taint = strings.TrimSpace(taint)
+		key, effect := taint, ""
+		if i := strings.LastIndex(taint, ":"); i != -1 {
+			key, effect = taint[:i], taint[i+1:]
+		}
+		key = strings.SplitN(key, "=", 2)[0]
+		for _, nodeTaint := range node.Spec.Taints {
+			if nodeTaint.Key == key && (effect == "" || string(nodeTaint.Effect) == effect) {
+				return taint
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+package podlogs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// argoWorkflowLabel is the label Argo sets on the pods of a workflow, the workflow is named after the experiment
+const argoWorkflowLabel = "workflows.argoproj.io/workflow"
+
+// argoWaitContainer is the sidecar Argo adds to the pods of a workflow, its logs are left out
+const argoWaitContainer = "wait"
+
+// Options tunes the tailing
+type Options struct {
+	Output       io.Writer     // Receives the log lines prefixed by their pod and container, os.Stdout if nil
+	Interval     time.Duration // Delay between the lookups of new pods, 5 seconds if not set
+	DrainTimeout time.Duration // Time given to the open streams to reach the end of the logs once stopped, 10 seconds if not set
+	Keep         bool          // Keeps a copy of the logs of every container, returned by Logs
+}
+
+// Container is a container of a pod of the run
+type Container struct {
+	Namespace string
+	Pod       string
+	Name      string
+}
+
+// Tailer streams the logs of the pods of an experiment run as they come up, the pods of its Argo
+// workflow along with the runner, experiment and helper pods of its ChaosEngines
+type Tailer struct {
+	clients        environment.ClientSets
+	experimentName string
+	opts           Options
+
+	output        sync.Mutex // Keeps the lines of the streams from interleaving, and guards kept
+	streams       sync.WaitGroup
+	started       map[string]bool // Containers whose logs are streamed, by pod UID and container name
+	kept          map[Container]*bytes.Buffer
+	stopDiscovery context.CancelFunc
+	stopStreams   context.CancelFunc
+	streamCtx     context.Context
+	done          chan struct{}
+}
+
+// New returns a tailer of the pods of the experiment, with the defaults set for the options which aren't
+func New(clients environment.ClientSets, experimentName string, opts Options) *Tailer {
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = 10 * time.Second
+	}
+	return &Tailer{
+		clients:        clients,
+		experimentName: experimentName,
+		opts:           opts,
+		started:        map[string]bool{},
+		kept:           map[Container]*bytes.Buffer{},
+	}
+}
+
+// Start looks up the pods of the run every Interval and streams the logs of their containers
+// once they start, until Stop is called or the context is cancelled
+func (t *Tailer) Start(ctx context.Context) {
+	t.streamCtx, t.stopStreams = context.WithCancel(ctx)
+	discoveryCtx, stopDiscovery := context.WithCancel(t.streamCtx)
+	t.stopDiscovery = stopDiscovery
+	t.done = make(chan struct{})
+
+	klog.Infof("[Logs]: Tailing the logs of the pods of experiment %s", t.experimentName)
+	go func() {
+		defer close(t.done)
+		for {
+			t.discover()
+			select {
+			case <-discoveryCtx.Done():
+				return
+			case <-time.After(t.opts.Interval):
+			}
+		}
+	}()
+}
+
+// Stop stops looking up new pods, after a last lookup for the pods which came up since the previous one,
+// and waits for the open streams to end, up to DrainTimeout
+func (t *Tailer) Stop() {
+	t.stopDiscovery()
+	<-t.done
+	t.discover()
+
+	drained := make(chan struct{})
+	go func() {
+		t.streams.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(t.opts.DrainTimeout):
+		klog.Warningf("[Logs]: Logs of experiment %s still streaming after %v, closing them", t.experimentName, t.opts.DrainTimeout)
+	}
+	t.stopStreams()
+	t.streams.Wait()
+}
+
+// Logs returns the logs kept by the tailer, by container. They outlive the pods, which the workflow
+// deletes once it completes, and are complete for the containers which ended before Stop.
+func (t *Tailer) Logs() map[Container][]byte {
+	t.output.Lock()
+	defer t.output.Unlock()
+	logs := make(map[Container][]byte, len(t.kept))
+	for container, buf := range t.kept {
+		logs[container] = bytes.Clone(buf.Bytes())
+	}
+	return logs
+}
+
+// discover streams the logs of the containers of the pods of the run which started since the last lookup
+func (t *Tailer) discover() {
+	if t.streamCtx.Err() != nil {
+		return
+	}
+	pods, err := Pods(t.clients, t.experimentName)
+	if err != nil {
+		klog.Warningf("[Logs]: Unable to look up the pods of experiment %s: %v", t.experimentName, err)
+		return
+	}
+
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			key := string(pod.UID) + "/" + status.Name
+			if t.started[key] || (status.State.Running == nil && status.State.Terminated == nil) {
+				continue
+			}
+			if pod.Labels[argoWorkflowLabel] != "" && status.Name == argoWaitContainer {
+				continue
+			}
+			t.started[key] = true
+			t.streams.Add(1)
+			go t.stream(pod, status.Name)
+		}
+	}
+}
+
+// Pods returns the pods of the Argo workflow of the experiment run and the runner, experiment and helper pods of its ChaosEngines
+func Pods(clients environment.ClientSets, experimentName string) ([]v1.Pod, error) {
+	workflowPods, err := clients.KubeClient.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: argoWorkflowLabel + "=" + experimentName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the workflow pods: %v", err)
+	}
+	pods := workflowPods.Items
+
+	engines, err := clients.LitmusClient.ChaosEngines(metav1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: "workflow_name=" + experimentName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the chaos engines: %v", err)
+	}
+	for _, engine := range engines.Items {
+		chaosPods, err := clients.KubeClient.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+			LabelSelector: "chaosUID=" + string(engine.UID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the pods of chaos engine %s: %v", engine.Name, err)
+		}
+		pods = append(pods, chaosPods.Items...)
+	}
+	return pods, nil
+}
+
+// stream writes the logs of the container, prefixed by the pod and container, until they end
+func (t *Tailer) stream(pod v1.Pod, container string) {
+	defer t.streams.Done()
+
+	req := t.clients.KubeClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: container,
+		Follow:    true,
+	})
+	readCloser, err := req.Context(t.streamCtx).Stream()
+	if err != nil {
+		if t.streamCtx.Err() == nil {
+			klog.Warningf("[Logs]: Unable to stream the logs of %s/%s: %v", pod.Name, container, err)
+		}
+		return
+	}
+	defer readCloser.Close()
+
+	var kept *bytes.Buffer
+	if t.opts.Keep {
+		kept = &bytes.Buffer{}
+		t.output.Lock()
+		t.kept[Container{Namespace: pod.Namespace, Pod: pod.Name, Name: container}] = kept
+		t.output.Unlock()
+	}
+
+	prefix := fmt.Sprintf("[%s/%s] ", pod.Name, container)
+	scanner := bufio.NewScanner(readCloser)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		t.output.Lock()
+		fmt.Fprintln(t.opts.Output, prefix+scanner.Text())
+		if kept != nil {
+			kept.Write(scanner.Bytes())
+			kept.WriteByte('\n')
+		}
+		t.output.Unlock()
+	}
+	// A read error or a line longer than the buffer ends the stream, the rest of the logs of the container is lost
+	if err := scanner.Err(); err != nil && t.streamCtx.Err() == nil {
+		klog.Warningf("[Logs]: Stopped streaming the logs of %s/%s: %v", pod.Name, container, err)
+	}
+}
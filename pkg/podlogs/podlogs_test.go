@@ -0,0 +1,149 @@
+package podlogs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	chaosClient "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/typed/litmuschaos/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// runAPI serves a cluster with the workflow pod of the run and the runner pod of its ChaosEngine,
+// and records the containers whose logs were requested
+func runAPI(t *testing.T, logs map[string]string) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/pods":
+			switch r.URL.Query().Get("labelSelector") {
+			case argoWorkflowLabel + "=pod-delete-abc12":
+				io.WriteString(w, `{"apiVersion":"v1","kind":"PodList","items":[{"metadata":{"name":"pod-delete-abc12-1","namespace":"litmus","uid":"uid-workflow","labels":{"workflows.argoproj.io/workflow":"pod-delete-abc12"}},"status":{"containerStatuses":[{"name":"main","state":{"terminated":{}}},{"name":"wait","state":{"running":{}}}]}}]}`)
+			case "chaosUID=uid-engine":
+				io.WriteString(w, `{"apiVersion":"v1","kind":"PodList","items":[{"metadata":{"name":"pod-delete-ce5x7k-runner","namespace":"litmus","uid":"uid-runner"},"status":{"containerStatuses":[{"name":"chaos-runner","state":{"terminated":{}}},{"name":"sidecar","state":{"waiting":{}}}]}}]}`)
+			default:
+				io.WriteString(w, `{"apiVersion":"v1","kind":"PodList","items":[]}`)
+			}
+		case "/apis/litmuschaos.io/v1alpha1/chaosengines":
+			io.WriteString(w, `{"apiVersion":"litmuschaos.io/v1alpha1","kind":"ChaosEngineList","items":[{"metadata":{"name":"pod-delete-ce5x7k","namespace":"litmus","uid":"uid-engine"}}]}`)
+		case "/api/v1/namespaces/litmus/pods/pod-delete-abc12-1/log", "/api/v1/namespaces/litmus/pods/pod-delete-ce5x7k-runner/log":
+			container := r.URL.Query().Get("container")
+			mu.Lock()
+			requested = append(requested, container)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "text/plain")
+			io.WriteString(w, logs[container])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), requested...)
+	}
+}
+
+// newClients returns the clients of the served cluster
+func newClients(t *testing.T, srv *httptest.Server) environment.ClientSets {
+	t.Helper()
+	config := &rest.Config{Host: srv.URL}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create the kube client: %v", err)
+	}
+	litmusClient, err := chaosClient.NewForConfig(config)
+	if err != nil {
+		t.Fatalf("failed to create the litmus client: %v", err)
+	}
+	return environment.ClientSets{KubeClient: kubeClient, LitmusClient: litmusClient}
+}
+
+// syncBuffer is a buffer the test reads while the tailer writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestTailer(t *testing.T) {
+	srv, requested := runAPI(t, map[string]string{
+		"main":         "install-chaos-faults started\ninstall-chaos-faults done\n",
+		"wait":         "argo wait sidecar\n",
+		"chaos-runner": "experiment job created\n",
+	})
+	output := &syncBuffer{}
+	tailer := New(newClients(t, srv), "pod-delete-abc12", Options{Output: output, Interval: 10 * time.Millisecond, Keep: true})
+	tailer.Start(context.Background())
+	tailer.Stop()
+
+	for _, line := range []string{
+		"[pod-delete-abc12-1/main] install-chaos-faults started",
+		"[pod-delete-abc12-1/main] install-chaos-faults done",
+		"[pod-delete-ce5x7k-runner/chaos-runner] experiment job created",
+	} {
+		if !strings.Contains(output.String(), line+"\n") {
+			t.Errorf("expected the line %q in the output, got:\n%s", line, output.String())
+		}
+	}
+	if strings.Contains(output.String(), "argo wait sidecar") {
+		t.Errorf("expected the logs of the wait container to be left out, got:\n%s", output.String())
+	}
+
+	// Every container is streamed once, the waiting one and the wait sidecar aren't
+	if got := requested(); len(got) != 2 {
+		t.Errorf("expected the logs of main and chaos-runner to be streamed once, got %v", got)
+	}
+
+	logs := tailer.Logs()
+	want := map[Container]string{
+		{Namespace: "litmus", Pod: "pod-delete-abc12-1", Name: "main"}:               "install-chaos-faults started\ninstall-chaos-faults done\n",
+		{Namespace: "litmus", Pod: "pod-delete-ce5x7k-runner", Name: "chaos-runner"}: "experiment job created\n",
+	}
+	if len(logs) != len(want) {
+		t.Errorf("expected the logs of %d containers to be kept, got %d", len(want), len(logs))
+	}
+	for container, wantLogs := range want {
+		if got := string(logs[container]); got != wantLogs {
+			t.Errorf("expected the kept logs of %v to be %q, got %q", container, wantLogs, got)
+		}
+	}
+}
+
+func TestTailerLongLine(t *testing.T) {
+	srv, _ := runAPI(t, map[string]string{
+		"main": "before\n" + strings.Repeat("x", 2*1024*1024) + "\nafter\n",
+	})
+	tailer := New(newClients(t, srv), "pod-delete-abc12", Options{Output: io.Discard, Interval: 10 * time.Millisecond, Keep: true})
+	tailer.Start(context.Background())
+	tailer.Stop()
+
+	// The stream of the container ends at the line over the buffer
+	main := Container{Namespace: "litmus", Pod: "pod-delete-abc12-1", Name: "main"}
+	if got := string(tailer.Logs()[main]); got != "before\n" {
+		t.Errorf("expected the kept logs to end before the long line, got %d bytes", len(got))
+	}
+}
@@ -0,0 +1,446 @@
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/pkg/errors"
+)
+
+// Definition describes a ChaosCenter probe, it follows the probe schema of the ChaosEngine
+type Definition struct {
+	Name          string        `json:"name"`
+	Type          string        `json:"type"`
+	Mode          string        `json:"mode,omitempty"` // Mode of the probe when it is inlined in the ChaosEngine, SOT if empty
+	Description   string        `json:"description,omitempty"`
+	Tags          []string      `json:"tags,omitempty"`
+	RunProperties RunProperties `json:"runProperties"`
+	HTTPInputs    *HTTPInputs   `json:"httpProbe/inputs,omitempty"`
+	CmdInputs     *CmdInputs    `json:"cmdProbe/inputs,omitempty"`
+	K8sInputs     *K8sInputs    `json:"k8sProbe/inputs,omitempty"`
+	PromInputs    *PromInputs   `json:"promProbe/inputs,omitempty"`
+}
+
+// RunProperties holds the timeouts and retries of the probe
+type RunProperties struct {
+	ProbeTimeout         string `json:"probeTimeout"`
+	Interval             string `json:"interval"`
+	Attempt              int    `json:"attempt,omitempty"`
+	Retry                int    `json:"retry,omitempty"`
+	ProbePollingInterval string `json:"probePollingInterval,omitempty"`
+	InitialDelay         string `json:"initialDelay,omitempty"`
+	StopOnFailure        bool   `json:"stopOnFailure,omitempty"`
+}
+
+// HTTPInputs are the inputs of the httpProbe
+type HTTPInputs struct {
+	URL                string     `json:"url"`
+	InsecureSkipVerify bool       `json:"insecureSkipVerify,omitempty"`
+	Method             HTTPMethod `json:"method"`
+}
+
+// HTTPMethod holds the request of the httpProbe, only one of them is set
+type HTTPMethod struct {
+	Get  *HTTPGet  `json:"get,omitempty"`
+	Post *HTTPPost `json:"post,omitempty"`
+}
+
+// HTTPGet is a GET request with the expected response code
+type HTTPGet struct {
+	Criteria     string `json:"criteria"`
+	ResponseCode string `json:"responseCode"`
+}
+
+// HTTPPost is a POST request with the expected response code, the body is either inline or read from BodyPath
+type HTTPPost struct {
+	ContentType  string `json:"contentType,omitempty"`
+	Body         string `json:"body,omitempty"`
+	BodyPath     string `json:"bodyPath,omitempty"`
+	Criteria     string `json:"criteria"`
+	ResponseCode string `json:"responseCode"`
+}
+
+// CmdInputs are the inputs of the cmdProbe
+type CmdInputs struct {
+	Command    string     `json:"command"`
+	Source     *Source    `json:"source,omitempty"`
+	Comparator Comparator `json:"comparator"`
+}
+
+// Source is the pod the cmdProbe command runs in, the command runs inside the experiment pod without it
+type Source struct {
+	Image           string `json:"image"`
+	ImagePullPolicy string `json:"imagePullPolicy,omitempty"`
+	HostNetwork     bool   `json:"hostNetwork,omitempty"`
+}
+
+// K8sInputs are the inputs of the k8sProbe
+type K8sInputs struct {
+	Group         string `json:"group,omitempty"`
+	Version       string `json:"version"`
+	Resource      string `json:"resource"`
+	Namespace     string `json:"namespace,omitempty"`
+	ResourceNames string `json:"resourceNames,omitempty"`
+	FieldSelector string `json:"fieldSelector,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	Operation     string `json:"operation"`
+}
+
+// PromInputs are the inputs of the promProbe, the query is either inline or read from QueryPath
+type PromInputs struct {
+	Endpoint   string     `json:"endpoint"`
+	Query      string     `json:"query,omitempty"`
+	QueryPath  string     `json:"queryPath,omitempty"`
+	Comparator Comparator `json:"comparator"`
+}
+
+// Comparator compares the output of the cmdProbe or promProbe with the expected value
+type Comparator struct {
+	Type     string `json:"type"`
+	Criteria string `json:"criteria"`
+	Value    string `json:"value"`
+}
+
+var (
+	// Modes lists the modes a probe can run in
+	Modes = []string{"SOT", "EOT", "Edge", "Continuous", "OnChaos"}
+
+	httpCriteria    = []string{"==", "!=", "oneOf"}
+	k8sOperations   = []string{"present", "absent", "create", "delete"}
+	numericCriteria = []string{"==", "!=", "<", ">", "<=", ">=", "OneOf", "Between"}
+	stringCriteria  = []string{"equal", "notEqual", "contains", "matches", "notMatches", "oneOf"}
+)
+
+// Load reads and validates the probe definition at the given path
+func Load(path string) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the probe definition %s", path)
+	}
+
+	var definition Definition
+	if err := yamlChe.Unmarshal(data, &definition); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the probe definition %s", path)
+	}
+	if err := definition.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid probe definition %s", path)
+	}
+	return &definition, nil
+}
+
+// FromExperimentDetails returns the definition of the probe to create, it is loaded from
+// LITMUS_PROBE_DEFINITION if set and built from the probe ENVs otherwise
+func FromExperimentDetails(experimentsDetails *types.ExperimentDetails) (*Definition, error) {
+	if experimentsDetails.ProbeDefinitionPath != "" {
+		return Load(experimentsDetails.ProbeDefinitionPath)
+	}
+
+	definition := Definition{
+		Name: experimentsDetails.ProbeName,
+		Type: experimentsDetails.ProbeType,
+		RunProperties: RunProperties{
+			ProbeTimeout: experimentsDetails.ProbeTimeout,
+			Interval:     experimentsDetails.ProbeInterval,
+			Attempt:      experimentsDetails.ProbeAttempts,
+		},
+	}
+
+	switch models.ProbeType(experimentsDetails.ProbeType) {
+	case models.ProbeTypeHTTPProbe:
+		definition.HTTPInputs = &HTTPInputs{
+			URL:                experimentsDetails.ProbeURL,
+			InsecureSkipVerify: true,
+		}
+		if strings.EqualFold(experimentsDetails.ProbeHTTPMethod, "POST") {
+			definition.HTTPInputs.Method.Post = &HTTPPost{
+				ContentType:  experimentsDetails.ProbeHTTPContentType,
+				Body:         experimentsDetails.ProbeHTTPBody,
+				Criteria:     "==",
+				ResponseCode: experimentsDetails.ProbeResponseCode,
+			}
+		} else {
+			definition.HTTPInputs.Method.Get = &HTTPGet{
+				Criteria:     "==",
+				ResponseCode: experimentsDetails.ProbeResponseCode,
+			}
+		}
+	case models.ProbeTypeCmdProbe:
+		definition.CmdInputs = &CmdInputs{
+			Command:    experimentsDetails.ProbeCommand,
+			Comparator: comparatorFromDetails(experimentsDetails, Comparator{Type: "string", Criteria: "contains", Value: "total"}),
+		}
+		if experimentsDetails.ProbeSourceImage != "" {
+			definition.CmdInputs.Source = &Source{Image: experimentsDetails.ProbeSourceImage}
+		}
+	case models.ProbeTypeK8sProbe:
+		definition.K8sInputs = &K8sInputs{
+			Group:         experimentsDetails.ProbeK8sGroup,
+			Version:       experimentsDetails.ProbeK8sVersion,
+			Resource:      experimentsDetails.ProbeK8sResource,
+			Namespace:     experimentsDetails.ProbeK8sNamespace,
+			ResourceNames: experimentsDetails.ProbeK8sResourceNames,
+			FieldSelector: experimentsDetails.ProbeK8sFieldSelector,
+			LabelSelector: experimentsDetails.ProbeK8sLabelSelector,
+			Operation:     experimentsDetails.ProbeK8sOperation,
+		}
+	case models.ProbeTypePromProbe:
+		definition.PromInputs = &PromInputs{
+			Endpoint:   experimentsDetails.ProbePromEndpoint,
+			Query:      experimentsDetails.ProbePromQuery,
+			Comparator: comparatorFromDetails(experimentsDetails, Comparator{Type: "float"}),
+		}
+	}
+
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// comparatorFromDetails returns the comparator set by the ENVs, with the defaults of the probe type
+func comparatorFromDetails(experimentsDetails *types.ExperimentDetails, defaults Comparator) Comparator {
+	comparator := Comparator{
+		Type:     experimentsDetails.ProbeComparatorType,
+		Criteria: experimentsDetails.ProbeComparatorCriteria,
+		Value:    experimentsDetails.ProbeComparatorValue,
+	}
+	if comparator.Type == "" {
+		comparator.Type = defaults.Type
+	}
+	if comparator.Criteria == "" {
+		comparator.Criteria = defaults.Criteria
+	}
+	if comparator.Value == "" {
+		comparator.Value = defaults.Value
+	}
+	return comparator
+}
+
+// Validate checks that the definition has the inputs its probe type needs
+func (d *Definition) Validate() error {
+	if d.Name == "" {
+		return errors.Errorf("probe has no name")
+	}
+	if d.Mode != "" && !slices.Contains(Modes, d.Mode) {
+		return errors.Errorf("probe %s has an invalid mode %q, it must be one of %v", d.Name, d.Mode, Modes)
+	}
+	if d.RunProperties.ProbeTimeout == "" || d.RunProperties.Interval == "" {
+		return errors.Errorf("probe %s needs runProperties.probeTimeout and runProperties.interval", d.Name)
+	}
+
+	switch models.ProbeType(d.Type) {
+	case models.ProbeTypeHTTPProbe:
+		return d.validateHTTP()
+	case models.ProbeTypeCmdProbe:
+		if d.CmdInputs == nil || d.CmdInputs.Command == "" {
+			return errors.Errorf("cmdProbe %s has no command", d.Name)
+		}
+		if d.CmdInputs.Source != nil && d.CmdInputs.Source.Image == "" {
+			return errors.Errorf("cmdProbe %s has a source without an image", d.Name)
+		}
+		return d.CmdInputs.Comparator.validate(d.Name)
+	case models.ProbeTypeK8sProbe:
+		if d.K8sInputs == nil || d.K8sInputs.Version == "" || d.K8sInputs.Resource == "" {
+			return errors.Errorf("k8sProbe %s needs a version and a resource", d.Name)
+		}
+		if !slices.Contains(k8sOperations, d.K8sInputs.Operation) {
+			return errors.Errorf("k8sProbe %s has an invalid operation %q, it must be one of %v", d.Name, d.K8sInputs.Operation, k8sOperations)
+		}
+		return nil
+	case models.ProbeTypePromProbe:
+		if d.PromInputs == nil || d.PromInputs.Endpoint == "" {
+			return errors.Errorf("promProbe %s has no endpoint", d.Name)
+		}
+		if (d.PromInputs.Query == "") == (d.PromInputs.QueryPath == "") {
+			return errors.Errorf("promProbe %s needs either a query or a queryPath", d.Name)
+		}
+		return d.PromInputs.Comparator.validate(d.Name)
+	default:
+		return errors.Errorf("probe %s has an unsupported type %q", d.Name, d.Type)
+	}
+}
+
+// validateHTTP checks the inputs of the httpProbe
+func (d *Definition) validateHTTP() error {
+	if d.HTTPInputs == nil || d.HTTPInputs.URL == "" {
+		return errors.Errorf("httpProbe %s has no url", d.Name)
+	}
+	method := d.HTTPInputs.Method
+	if (method.Get == nil) == (method.Post == nil) {
+		return errors.Errorf("httpProbe %s needs either a get or a post method", d.Name)
+	}
+	criteria, responseCode := "", ""
+	if method.Get != nil {
+		criteria, responseCode = method.Get.Criteria, method.Get.ResponseCode
+	} else {
+		if method.Post.Body != "" && method.Post.BodyPath != "" {
+			return errors.Errorf("httpProbe %s has both a body and a bodyPath", d.Name)
+		}
+		criteria, responseCode = method.Post.Criteria, method.Post.ResponseCode
+	}
+	if responseCode == "" {
+		return errors.Errorf("httpProbe %s has no responseCode", d.Name)
+	}
+	if !slices.Contains(httpCriteria, criteria) {
+		return errors.Errorf("httpProbe %s has an invalid criteria %q, it must be one of %v", d.Name, criteria, httpCriteria)
+	}
+	return nil
+}
+
+// validate checks that the criteria of the comparator suits its type
+func (c Comparator) validate(probeName string) error {
+	var criteria []string
+	switch c.Type {
+	case "int", "float":
+		criteria = numericCriteria
+	case "string":
+		criteria = stringCriteria
+	default:
+		return errors.Errorf("probe %s has an invalid comparator type %q, it must be int, float or string", probeName, c.Type)
+	}
+	if !slices.Contains(criteria, c.Criteria) {
+		return errors.Errorf("probe %s has an invalid comparator criteria %q for type %s, it must be one of %v", probeName, c.Criteria, c.Type, criteria)
+	}
+	if c.Value == "" {
+		return errors.Errorf("probe %s has no comparator value", probeName)
+	}
+	return nil
+}
+
+// Request returns the request which creates the probe in ChaosCenter
+func (d *Definition) Request() (*models.ProbeRequest, error) {
+	description := d.Description
+	if description == "" {
+		description = fmt.Sprintf("%s for %s", d.Type, d.Name)
+	}
+	tags := d.Tags
+	if len(tags) == 0 {
+		tags = []string{strings.TrimSuffix(d.Type, "Probe"), "probe", "chaos"}
+	}
+
+	request := &models.ProbeRequest{
+		Name:               d.Name,
+		Description:        &description,
+		Tags:               tags,
+		Type:               models.ProbeType(d.Type),
+		InfrastructureType: models.InfrastructureTypeKubernetes,
+	}
+
+	run := d.RunProperties
+	switch models.ProbeType(d.Type) {
+	case models.ProbeTypeHTTPProbe:
+		request.KubernetesHTTPProperties = &models.KubernetesHTTPProbeRequest{
+			ProbeTimeout:         run.ProbeTimeout,
+			Interval:             run.Interval,
+			Retry:                optionalInt(run.Retry),
+			Attempt:              optionalInt(run.Attempt),
+			ProbePollingInterval: optionalString(run.ProbePollingInterval),
+			InitialDelay:         optionalString(run.InitialDelay),
+			StopOnFailure:        &run.StopOnFailure,
+			URL:                  d.HTTPInputs.URL,
+			Method:               &models.MethodRequest{},
+			InsecureSkipVerify:   &d.HTTPInputs.InsecureSkipVerify,
+		}
+		if get := d.HTTPInputs.Method.Get; get != nil {
+			request.KubernetesHTTPProperties.Method.Get = &models.GETRequest{
+				Criteria:     get.Criteria,
+				ResponseCode: get.ResponseCode,
+			}
+		} else {
+			post := d.HTTPInputs.Method.Post
+			request.KubernetesHTTPProperties.Method.Post = &models.POSTRequest{
+				ContentType:  optionalString(post.ContentType),
+				Body:         optionalString(post.Body),
+				BodyPath:     optionalString(post.BodyPath),
+				Criteria:     post.Criteria,
+				ResponseCode: post.ResponseCode,
+			}
+		}
+	case models.ProbeTypeCmdProbe:
+		request.KubernetesCMDProperties = &models.KubernetesCMDProbeRequest{
+			ProbeTimeout:         run.ProbeTimeout,
+			Interval:             run.Interval,
+			Retry:                optionalInt(run.Retry),
+			Attempt:              optionalInt(run.Attempt),
+			ProbePollingInterval: optionalString(run.ProbePollingInterval),
+			InitialDelay:         optionalString(run.InitialDelay),
+			StopOnFailure:        &run.StopOnFailure,
+			Command:              d.CmdInputs.Command,
+			Comparator:           d.CmdInputs.Comparator.input(),
+		}
+		// The source is passed to ChaosCenter as a JSON document
+		if d.CmdInputs.Source != nil {
+			source, err := json.Marshal(d.CmdInputs.Source)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to marshal the source of probe %s", d.Name)
+			}
+			request.KubernetesCMDProperties.Source = optionalString(string(source))
+		}
+	case models.ProbeTypeK8sProbe:
+		request.K8sProperties = &models.K8SProbeRequest{
+			ProbeTimeout:         run.ProbeTimeout,
+			Interval:             run.Interval,
+			Retry:                optionalInt(run.Retry),
+			Attempt:              optionalInt(run.Attempt),
+			ProbePollingInterval: optionalString(run.ProbePollingInterval),
+			InitialDelay:         optionalString(run.InitialDelay),
+			StopOnFailure:        &run.StopOnFailure,
+			Group:                optionalString(d.K8sInputs.Group),
+			Version:              d.K8sInputs.Version,
+			Resource:             d.K8sInputs.Resource,
+			Namespace:            optionalString(d.K8sInputs.Namespace),
+			ResourceNames:        optionalString(d.K8sInputs.ResourceNames),
+			FieldSelector:        optionalString(d.K8sInputs.FieldSelector),
+			LabelSelector:        optionalString(d.K8sInputs.LabelSelector),
+			Operation:            d.K8sInputs.Operation,
+		}
+	case models.ProbeTypePromProbe:
+		request.PromProperties = &models.PROMProbeRequest{
+			ProbeTimeout:         run.ProbeTimeout,
+			Interval:             run.Interval,
+			Retry:                optionalInt(run.Retry),
+			Attempt:              optionalInt(run.Attempt),
+			ProbePollingInterval: optionalString(run.ProbePollingInterval),
+			InitialDelay:         optionalString(run.InitialDelay),
+			StopOnFailure:        &run.StopOnFailure,
+			Endpoint:             d.PromInputs.Endpoint,
+			Query:                optionalString(d.PromInputs.Query),
+			QueryPath:            optionalString(d.PromInputs.QueryPath),
+			Comparator:           d.PromInputs.Comparator.input(),
+		}
+	default:
+		return nil, errors.Errorf("probe %s has an unsupported type %q", d.Name, d.Type)
+	}
+	return request, nil
+}
+
+// input returns the comparator of the probe request
+func (c Comparator) input() *models.ComparatorInput {
+	return &models.ComparatorInput{
+		Type:     c.Type,
+		Criteria: c.Criteria,
+		Value:    c.Value,
+	}
+}
+
+// optionalString returns nil for the empty string, so that the field is left out of the request
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// optionalInt returns nil for zero, so that the server default is used
+func optionalInt(value int) *int {
+	if value == 0 {
+		return nil
+	}
+	return &value
+}
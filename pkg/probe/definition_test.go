@@ -0,0 +1,410 @@
+package probe
+
+import (
+	"strings"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+var testRunProperties = RunProperties{ProbeTimeout: "5s", Interval: "2s"}
+
+func httpGetDefinition() Definition {
+	return Definition{
+		Name:          "check-app",
+		Type:          "httpProbe",
+		RunProperties: testRunProperties,
+		HTTPInputs: &HTTPInputs{
+			URL:    "http://app.default.svc:8080",
+			Method: HTTPMethod{Get: &HTTPGet{Criteria: "==", ResponseCode: "200"}},
+		},
+	}
+}
+
+func httpPostDefinition() Definition {
+	return Definition{
+		Name:          "post-order",
+		Type:          "httpProbe",
+		RunProperties: testRunProperties,
+		HTTPInputs: &HTTPInputs{
+			URL:    "http://app.default.svc:8080/orders",
+			Method: HTTPMethod{Post: &HTTPPost{ContentType: "application/json", Body: `{"item":1}`, Criteria: "==", ResponseCode: "201"}},
+		},
+	}
+}
+
+func cmdDefinitionWithSource() Definition {
+	return Definition{
+		Name:          "check-db",
+		Type:          "cmdProbe",
+		RunProperties: testRunProperties,
+		CmdInputs: &CmdInputs{
+			Command:    "pg_isready -h db",
+			Source:     &Source{Image: "postgres:16", HostNetwork: true},
+			Comparator: Comparator{Type: "string", Criteria: "contains", Value: "accepting connections"},
+		},
+	}
+}
+
+func k8sDefinition() Definition {
+	return Definition{
+		Name:          "check-pods",
+		Type:          "k8sProbe",
+		RunProperties: testRunProperties,
+		K8sInputs:     &K8sInputs{Version: "v1", Resource: "pods", Namespace: "shop", LabelSelector: "app=cart", Operation: "present"},
+	}
+}
+
+func promDefinition() Definition {
+	return Definition{
+		Name:          "error-rate",
+		Type:          "promProbe",
+		RunProperties: testRunProperties,
+		PromInputs: &PromInputs{
+			Endpoint:   "http://prometheus.monitoring:9090",
+			Query:      `sum(rate(http_requests_total{code=~"5.."}[1m]))`,
+			Comparator: Comparator{Type: "float", Criteria: "<=", Value: "0.05"},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition func() Definition
+		wantErr    string
+	}{
+		{name: "httpProbe get", definition: httpGetDefinition},
+		{name: "httpProbe post", definition: httpPostDefinition},
+		{name: "cmdProbe", definition: cmdDefinitionWithSource},
+		{name: "k8sProbe", definition: k8sDefinition},
+		{name: "promProbe", definition: promDefinition},
+		{
+			name: "no name",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.Name = ""
+				return d
+			},
+			wantErr: "probe has no name",
+		},
+		{
+			name: "invalid mode",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.Mode = "Always"
+				return d
+			},
+			wantErr: `invalid mode "Always"`,
+		},
+		{
+			name: "missing run properties",
+			definition: func() Definition {
+				d := k8sDefinition()
+				d.RunProperties.Interval = ""
+				return d
+			},
+			wantErr: "needs runProperties.probeTimeout and runProperties.interval",
+		},
+		{
+			name: "unsupported type",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.Type = "sloProbe"
+				return d
+			},
+			wantErr: `unsupported type "sloProbe"`,
+		},
+		{
+			name: "httpProbe without url",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.HTTPInputs.URL = ""
+				return d
+			},
+			wantErr: "httpProbe check-app has no url",
+		},
+		{
+			name: "httpProbe with get and post",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.HTTPInputs.Method.Post = httpPostDefinition().HTTPInputs.Method.Post
+				return d
+			},
+			wantErr: "needs either a get or a post method",
+		},
+		{
+			name: "httpProbe with body and bodyPath",
+			definition: func() Definition {
+				d := httpPostDefinition()
+				d.HTTPInputs.Method.Post.BodyPath = "/data/order.json"
+				return d
+			},
+			wantErr: "httpProbe post-order has both a body and a bodyPath",
+		},
+		{
+			name: "httpProbe without responseCode",
+			definition: func() Definition {
+				d := httpGetDefinition()
+				d.HTTPInputs.Method.Get.ResponseCode = ""
+				return d
+			},
+			wantErr: "httpProbe check-app has no responseCode",
+		},
+		{
+			name: "httpProbe with invalid criteria",
+			definition: func() Definition {
+				d := httpPostDefinition()
+				d.HTTPInputs.Method.Post.Criteria = "<"
+				return d
+			},
+			wantErr: `httpProbe post-order has an invalid criteria "<"`,
+		},
+		{
+			name: "cmdProbe without command",
+			definition: func() Definition {
+				d := cmdDefinitionWithSource()
+				d.CmdInputs.Command = ""
+				return d
+			},
+			wantErr: "cmdProbe check-db has no command",
+		},
+		{
+			name: "cmdProbe source without image",
+			definition: func() Definition {
+				d := cmdDefinitionWithSource()
+				d.CmdInputs.Source.Image = ""
+				return d
+			},
+			wantErr: "cmdProbe check-db has a source without an image",
+		},
+		{
+			name: "cmdProbe with numeric criteria for a string",
+			definition: func() Definition {
+				d := cmdDefinitionWithSource()
+				d.CmdInputs.Comparator.Criteria = ">="
+				return d
+			},
+			wantErr: `invalid comparator criteria ">=" for type string`,
+		},
+		{
+			name: "cmdProbe with invalid comparator type",
+			definition: func() Definition {
+				d := cmdDefinitionWithSource()
+				d.CmdInputs.Comparator.Type = "bool"
+				return d
+			},
+			wantErr: `invalid comparator type "bool"`,
+		},
+		{
+			name: "k8sProbe without resource",
+			definition: func() Definition {
+				d := k8sDefinition()
+				d.K8sInputs.Resource = ""
+				return d
+			},
+			wantErr: "k8sProbe check-pods needs a version and a resource",
+		},
+		{
+			name: "k8sProbe with invalid operation",
+			definition: func() Definition {
+				d := k8sDefinition()
+				d.K8sInputs.Operation = "exists"
+				return d
+			},
+			wantErr: `k8sProbe check-pods has an invalid operation "exists"`,
+		},
+		{
+			name: "promProbe without endpoint",
+			definition: func() Definition {
+				d := promDefinition()
+				d.PromInputs.Endpoint = ""
+				return d
+			},
+			wantErr: "promProbe error-rate has no endpoint",
+		},
+		{
+			name: "promProbe with query and queryPath",
+			definition: func() Definition {
+				d := promDefinition()
+				d.PromInputs.QueryPath = "/queries/error-rate.promql"
+				return d
+			},
+			wantErr: "promProbe error-rate needs either a query or a queryPath",
+		},
+		{
+			name: "promProbe without query or queryPath",
+			definition: func() Definition {
+				d := promDefinition()
+				d.PromInputs.Query = ""
+				return d
+			},
+			wantErr: "promProbe error-rate needs either a query or a queryPath",
+		},
+		{
+			name: "promProbe with string criteria for a float",
+			definition: func() Definition {
+				d := promDefinition()
+				d.PromInputs.Comparator.Criteria = "contains"
+				return d
+			},
+			wantErr: `invalid comparator criteria "contains" for type float`,
+		},
+		{
+			name: "promProbe without comparator value",
+			definition: func() Definition {
+				d := promDefinition()
+				d.PromInputs.Comparator.Value = ""
+				return d
+			},
+			wantErr: "probe error-rate has no comparator value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			definition := tt.definition()
+			err := definition.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected the definition to be valid, got: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRequest(t *testing.T) {
+	t.Run("httpProbe get", func(t *testing.T) {
+		definition := httpGetDefinition()
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		if request.Name != "check-app" || request.Type != models.ProbeTypeHTTPProbe || request.InfrastructureType != models.InfrastructureTypeKubernetes {
+			t.Errorf("unexpected probe %s of type %s on %s", request.Name, request.Type, request.InfrastructureType)
+		}
+		if valueOf(request.Description) != "httpProbe for check-app" {
+			t.Errorf("expected the default description, got %q", valueOf(request.Description))
+		}
+		if strings.Join(request.Tags, ",") != "http,probe,chaos" {
+			t.Errorf("expected the default tags, got %v", request.Tags)
+		}
+		props := request.KubernetesHTTPProperties
+		if props == nil || props.URL != "http://app.default.svc:8080" || props.ProbeTimeout != "5s" || props.Interval != "2s" {
+			t.Fatalf("unexpected http properties %+v", props)
+		}
+		if props.Retry != nil || props.Attempt != nil || props.ProbePollingInterval != nil || props.InitialDelay != nil {
+			t.Errorf("expected the unset run properties to be left out of the request")
+		}
+		if props.Method.Post != nil || props.Method.Get == nil || props.Method.Get.Criteria != "==" || props.Method.Get.ResponseCode != "200" {
+			t.Errorf("unexpected method %+v", props.Method)
+		}
+		if request.KubernetesCMDProperties != nil || request.K8sProperties != nil || request.PromProperties != nil {
+			t.Errorf("expected only the http properties to be set")
+		}
+	})
+
+	t.Run("httpProbe post", func(t *testing.T) {
+		definition := httpPostDefinition()
+		definition.Description = "order checkout"
+		definition.Tags = []string{"checkout"}
+		definition.RunProperties.Attempt = 3
+		definition.RunProperties.InitialDelay = "10s"
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		if valueOf(request.Description) != "order checkout" || strings.Join(request.Tags, ",") != "checkout" {
+			t.Errorf("expected the description and tags of the definition, got %q and %v", valueOf(request.Description), request.Tags)
+		}
+		props := request.KubernetesHTTPProperties
+		if props.Attempt == nil || *props.Attempt != 3 || valueOf(props.InitialDelay) != "10s" || props.Retry != nil {
+			t.Errorf("expected only the attempt and initial delay of the run properties, got %+v", props)
+		}
+		post := props.Method.Post
+		if props.Method.Get != nil || post == nil {
+			t.Fatalf("expected a post method, got %+v", props.Method)
+		}
+		if valueOf(post.ContentType) != "application/json" || valueOf(post.Body) != `{"item":1}` || post.BodyPath != nil || post.ResponseCode != "201" {
+			t.Errorf("unexpected post %+v", post)
+		}
+	})
+
+	t.Run("cmdProbe with source", func(t *testing.T) {
+		definition := cmdDefinitionWithSource()
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		props := request.KubernetesCMDProperties
+		if props == nil || props.Command != "pg_isready -h db" {
+			t.Fatalf("unexpected cmd properties %+v", props)
+		}
+		if want := `{"image":"postgres:16","hostNetwork":true}`; valueOf(props.Source) != want {
+			t.Errorf("expected the source %s, got %s", want, valueOf(props.Source))
+		}
+		if *props.Comparator != (models.ComparatorInput{Type: "string", Criteria: "contains", Value: "accepting connections"}) {
+			t.Errorf("unexpected comparator %+v", props.Comparator)
+		}
+	})
+
+	t.Run("cmdProbe without source", func(t *testing.T) {
+		definition := cmdDefinitionWithSource()
+		definition.CmdInputs.Source = nil
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		if request.KubernetesCMDProperties.Source != nil {
+			t.Errorf("expected no source, got %s", valueOf(request.KubernetesCMDProperties.Source))
+		}
+	})
+
+	t.Run("k8sProbe", func(t *testing.T) {
+		definition := k8sDefinition()
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		props := request.K8sProperties
+		if props == nil || props.Version != "v1" || props.Resource != "pods" || props.Operation != "present" {
+			t.Fatalf("unexpected k8s properties %+v", props)
+		}
+		if valueOf(props.Namespace) != "shop" || valueOf(props.LabelSelector) != "app=cart" {
+			t.Errorf("expected the namespace and label selector of the definition, got %+v", props)
+		}
+		if props.Group != nil || props.ResourceNames != nil || props.FieldSelector != nil {
+			t.Errorf("expected the unset inputs to be left out of the request")
+		}
+	})
+
+	t.Run("promProbe", func(t *testing.T) {
+		definition := promDefinition()
+		request, err := definition.Request()
+		if err != nil {
+			t.Fatalf("Request returned an error: %v", err)
+		}
+		props := request.PromProperties
+		if props == nil || props.Endpoint != "http://prometheus.monitoring:9090" || valueOf(props.Query) != definition.PromInputs.Query {
+			t.Fatalf("unexpected prom properties %+v", props)
+		}
+		if props.QueryPath != nil {
+			t.Errorf("expected no queryPath, got %s", valueOf(props.QueryPath))
+		}
+		if *props.Comparator != (models.ComparatorInput{Type: "float", Criteria: "<=", Value: "0.05"}) {
+			t.Errorf("unexpected comparator %+v", props.Comparator)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		definition := httpGetDefinition()
+		definition.Type = "sloProbe"
+		if _, err := definition.Request(); err == nil {
+			t.Errorf("expected an error for the unsupported type")
+		}
+	})
+}
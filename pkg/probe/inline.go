@@ -0,0 +1,80 @@
+package probe
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// LoadAll reads and validates the probe definitions at the given paths, the probe names need to be unique
+func LoadAll(paths []string) ([]Definition, error) {
+	var definitions []Definition
+	names := map[string]bool{}
+	for _, path := range paths {
+		definition, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if names[definition.Name] {
+			return nil, errors.Errorf("probe %s of %s is defined more than once", definition.Name, path)
+		}
+		names[definition.Name] = true
+		definitions = append(definitions, *definition)
+	}
+	return definitions, nil
+}
+
+// EngineProbe returns the probe as it is inlined under spec.experiments[].spec.probe of the ChaosEngine.
+// It is built as an unstructured object since the probe types of the chaos-operator API in use
+// predate the duration strings of the run properties.
+func (d *Definition) EngineProbe() (map[string]interface{}, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal probe %s", d.Name)
+	}
+	engineProbe := map[string]interface{}{}
+	if err := json.Unmarshal(data, &engineProbe); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert probe %s", d.Name)
+	}
+
+	// The description and tags are only known to ChaosCenter
+	delete(engineProbe, "description")
+	delete(engineProbe, "tags")
+	if d.Mode == "" {
+		engineProbe["mode"] = "SOT"
+	}
+	return engineProbe, nil
+}
+
+// SetEngineProbes adds the inline probes to every experiment of the ChaosEngine, given as an unstructured object
+func SetEngineProbes(engine map[string]interface{}, definitions []Definition) error {
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	var engineProbes []interface{}
+	for i := range definitions {
+		engineProbe, err := definitions[i].EngineProbe()
+		if err != nil {
+			return err
+		}
+		engineProbes = append(engineProbes, engineProbe)
+	}
+
+	experiments, found, err := unstructured.NestedSlice(engine, "spec", "experiments")
+	if err != nil || !found {
+		return errors.Errorf("chaos engine has no experiments to add the probes to")
+	}
+	for _, item := range experiments {
+		experiment, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		existing, _, _ := unstructured.NestedSlice(experiment, "spec", "probe")
+		if err := unstructured.SetNestedSlice(experiment, append(existing, engineProbes...), "spec", "probe"); err != nil {
+			return errors.Wrapf(err, "failed to add the probes to the chaos engine")
+		}
+	}
+	return unstructured.SetNestedSlice(engine, experiments, "spec", "experiments")
+}
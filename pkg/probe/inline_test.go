@@ -0,0 +1,66 @@
+package probe
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSetEngineProbes(t *testing.T) {
+	engine := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"experiments": []interface{}{
+				map[string]interface{}{
+					"name": "pod-delete",
+					"spec": map[string]interface{}{
+						"probe": []interface{}{map[string]interface{}{"name": "existing"}},
+					},
+				},
+			},
+		},
+	}
+	definition := httpGetDefinition()
+	definition.Description = "checks the app"
+	definition.Tags = []string{"app"}
+
+	if err := SetEngineProbes(engine, []Definition{definition, cmdDefinitionWithSource()}); err != nil {
+		t.Fatalf("failed to set the probes: %v", err)
+	}
+
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	probes, _, _ := unstructured.NestedSlice(experiments[0].(map[string]interface{}), "spec", "probe")
+	if len(probes) != 3 {
+		t.Fatalf("expected the existing probe and the 2 inline probes, got %d", len(probes))
+	}
+
+	want := map[string]interface{}{
+		"name": "check-app",
+		"type": "httpProbe",
+		"mode": "SOT",
+		"runProperties": map[string]interface{}{
+			"probeTimeout": "5s",
+			"interval":     "2s",
+		},
+		"httpProbe/inputs": map[string]interface{}{
+			"url": "http://app.default.svc:8080",
+			"method": map[string]interface{}{
+				"get": map[string]interface{}{"criteria": "==", "responseCode": "200"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(probes[1], want) {
+		t.Errorf("expected the inline probe %v, got %v", want, probes[1])
+	}
+
+	cmdProbe := probes[2].(map[string]interface{})
+	if image, _, _ := unstructured.NestedString(cmdProbe, "cmdProbe/inputs", "source", "image"); image != "postgres:16" {
+		t.Errorf("expected the source of the cmdProbe to be inlined, got %v", cmdProbe)
+	}
+}
+
+func TestSetEngineProbesWithoutExperiments(t *testing.T) {
+	if err := SetEngineProbes(map[string]interface{}{}, []Definition{httpGetDefinition()}); err == nil {
+		t.Errorf("expected an error for a chaos engine without experiments")
+	}
+}
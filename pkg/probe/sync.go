@@ -0,0 +1,276 @@
+package probe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/pkg/errors"
+)
+
+// ManagedTag marks the probes created by the sync, only these probes are deleted
+// when their definition is removed, so that the other probes of the project are left alone
+const ManagedTag = "managed-by:chaos-ci-lib"
+
+// Store is the ChaosCenter project the probes are synced with
+type Store interface {
+	ListProbes(probeNames []string) ([]*models.Probe, error)
+	AddProbe(request models.ProbeRequest) (*models.Probe, error)
+	UpdateProbe(request models.ProbeRequest) error
+	DeleteProbe(probeName string) error
+}
+
+// Action is what the sync does with a probe
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionDelete    Action = "delete"
+	ActionUnchanged Action = "unchanged"
+)
+
+// Change is the action planned for a single probe
+type Change struct {
+	Action  Action
+	Name    string
+	Request *models.ProbeRequest // Request of the created or updated probe
+}
+
+// Plan is the list of changes which converge the project to the probe definitions
+type Plan []Change
+
+// LoadDir reads and validates the probe definitions of the .yaml and .yml files of the directory
+func LoadDir(dir string) ([]Definition, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the probe directory %s", dir)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	return LoadAll(paths)
+}
+
+// PlanSync returns the changes which converge the existing probes to the definitions. The probes without
+// a definition are only deleted if they carry the ManagedTag.
+func PlanSync(definitions []Definition, existing []*models.Probe) (Plan, error) {
+	existingProbes := map[string]*models.Probe{}
+	for _, probe := range existing {
+		existingProbes[probe.Name] = probe
+	}
+
+	var plan Plan
+	defined := map[string]bool{}
+	for i := range definitions {
+		request, err := definitions[i].Request()
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(request.Tags, ManagedTag) {
+			request.Tags = append(request.Tags, ManagedTag)
+		}
+		defined[request.Name] = true
+
+		current, ok := existingProbes[request.Name]
+		switch {
+		case !ok:
+			plan = append(plan, Change{Action: ActionCreate, Name: request.Name, Request: request})
+		case !matches(request, current):
+			plan = append(plan, Change{Action: ActionUpdate, Name: request.Name, Request: request})
+		default:
+			plan = append(plan, Change{Action: ActionUnchanged, Name: request.Name})
+		}
+	}
+
+	for _, probe := range existing {
+		if !defined[probe.Name] && slices.Contains(probe.Tags, ManagedTag) {
+			plan = append(plan, Change{Action: ActionDelete, Name: probe.Name})
+		}
+	}
+
+	sort.SliceStable(plan, func(i, j int) bool { return plan[i].Name < plan[j].Name })
+	return plan, nil
+}
+
+// matches checks if the existing probe already has every value set by the request
+func matches(request *models.ProbeRequest, current *models.Probe) bool {
+	if request.Type != current.Type || valueOf(request.Description) != valueOf(current.Description) {
+		return false
+	}
+	wantTags, currentTags := slices.Clone(request.Tags), slices.Clone(current.Tags)
+	sort.Strings(wantTags)
+	sort.Strings(currentTags)
+	if !slices.Equal(wantTags, currentTags) {
+		return false
+	}
+
+	// The properties are compared on their JSON form, since the request and response types differ.
+	// The values the server fills in, like the defaults of the run properties, aren't part of the request.
+	want, err := toMap(request)
+	if err != nil {
+		return false
+	}
+	got, err := toMap(current)
+	if err != nil {
+		return false
+	}
+	for _, key := range []string{"kubernetesHTTPProperties", "kubernetesCMDProperties", "k8sProperties", "promProperties"} {
+		if !isSubset(want[key], got[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset checks if every value of want is equal in got. The values holding a JSON document, like the
+// source of the cmdProbe, are compared on their parsed form since the server reformats them.
+func isSubset(want, got interface{}) bool {
+	if wantDoc, gotDoc, ok := parseDocuments(want, got); ok {
+		return isSubset(wantDoc, gotDoc)
+	}
+	wantMap, ok := want.(map[string]interface{})
+	if !ok {
+		return reflect.DeepEqual(want, got)
+	}
+	gotMap, ok := got.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for key, value := range wantMap {
+		if !isSubset(value, gotMap[key]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDocuments parses the values if both are strings holding a JSON object or array
+func parseDocuments(want, got interface{}) (interface{}, interface{}, bool) {
+	wantString, ok := want.(string)
+	if !ok {
+		return nil, nil, false
+	}
+	gotString, ok := got.(string)
+	if !ok || wantString == gotString {
+		return nil, nil, false
+	}
+	wantDoc, ok := parseDocument(wantString)
+	if !ok {
+		return nil, nil, false
+	}
+	gotDoc, ok := parseDocument(gotString)
+	if !ok {
+		return nil, nil, false
+	}
+	return wantDoc, gotDoc, true
+}
+
+// parseDocument parses the value if it is a JSON object or array
+func parseDocument(value string) (interface{}, bool) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return nil, false
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(trimmed), &doc); err != nil {
+		return nil, false
+	}
+	return doc, true
+}
+
+// toMap returns the JSON form of the value as a map
+func toMap(value interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	return obj, json.Unmarshal(data, &obj)
+}
+
+// valueOf returns the value of the string pointer, empty if nil
+func valueOf(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// Changed returns true if the plan has any create, update or delete
+func (p Plan) Changed() bool {
+	for _, change := range p {
+		if change.Action != ActionUnchanged {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the plan with a line per probe
+func (p Plan) String() string {
+	if len(p) == 0 {
+		return "no probes to sync"
+	}
+	var lines []string
+	for _, change := range p {
+		lines = append(lines, fmt.Sprintf("%-9s %s", change.Action, change.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Apply runs the changes of the plan against the store. Every change is attempted,
+// the probes which failed to converge are returned in the error.
+func (p Plan) Apply(store Store) error {
+	var failed []string
+	for _, change := range p {
+		var err error
+		switch change.Action {
+		case ActionCreate:
+			_, err = store.AddProbe(*change.Request)
+		case ActionUpdate:
+			err = store.UpdateProbe(*change.Request)
+		case ActionDelete:
+			err = store.DeleteProbe(change.Name)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s %s: %v", change.Action, change.Name, err))
+		}
+	}
+	if len(failed) != 0 {
+		return errors.Errorf("failed to sync the probes:\n - %s", strings.Join(failed, "\n - "))
+	}
+	return nil
+}
+
+// Sync converges the probes of the store to the definitions of the directory. With dryRun the
+// plan is only computed, nothing is changed.
+func Sync(dir string, store Store, dryRun bool) (Plan, error) {
+	definitions, err := LoadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := store.ListProbes(nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the probes of the project")
+	}
+	plan, err := PlanSync(definitions, existing)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return plan, nil
+	}
+	return plan, plan.Apply(store)
+}
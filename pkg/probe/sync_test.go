@@ -0,0 +1,71 @@
+package probe
+
+import (
+	"encoding/json"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// cmdDefinition returns a cmdProbe definition running its command in a source pod
+func cmdDefinition(image string) Definition {
+	return Definition{
+		Name:          "check-replicas",
+		Type:          string(models.ProbeTypeCmdProbe),
+		RunProperties: RunProperties{ProbeTimeout: "5s", Interval: "2s"},
+		CmdInputs: &CmdInputs{
+			Command:    "kubectl get deploy nginx -o jsonpath='{.status.readyReplicas}'",
+			Source:     &Source{Image: image},
+			Comparator: Comparator{Type: "int", Criteria: ">=", Value: "1"},
+		},
+	}
+}
+
+// storedProbe returns the probe ChaosCenter stores for the definition, with the source as the server returns it
+func storedProbe(t *testing.T, definition Definition, source string) *models.Probe {
+	t.Helper()
+	request, err := definition.Request()
+	if err != nil {
+		t.Fatalf("failed to build the probe request: %v", err)
+	}
+	request.Tags = append(request.Tags, ManagedTag)
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("failed to marshal the probe request: %v", err)
+	}
+	probe := &models.Probe{}
+	if err := json.Unmarshal(data, probe); err != nil {
+		t.Fatalf("failed to unmarshal the probe: %v", err)
+	}
+	probe.KubernetesCMDProperties.Source = &source
+	return probe
+}
+
+func TestPlanSyncIgnoresReformattedSource(t *testing.T) {
+	definition := cmdDefinition("chaosnative/k8s:latest")
+	existing := storedProbe(t, definition, `{
+  "hostNetwork": false,
+  "image": "chaosnative/k8s:latest",
+  "imagePullPolicy": ""
+}`)
+
+	plan, err := PlanSync([]Definition{definition}, []*models.Probe{existing})
+	if err != nil {
+		t.Fatalf("PlanSync returned an error: %v", err)
+	}
+	if plan.Changed() {
+		t.Errorf("expected no changes for a reformatted source, got:\n%s", plan)
+	}
+}
+
+func TestPlanSyncUpdatesChangedSource(t *testing.T) {
+	existing := storedProbe(t, cmdDefinition("chaosnative/k8s:latest"), `{"image": "chaosnative/k8s:latest"}`)
+
+	plan, err := PlanSync([]Definition{cmdDefinition("chaosnative/k8s:3.0.0")}, []*models.Probe{existing})
+	if err != nil {
+		t.Fatalf("PlanSync returned an error: %v", err)
+	}
+	if len(plan) != 1 || plan[0].Action != ActionUpdate {
+		t.Errorf("expected the probe to be updated, got:\n%s", plan)
+	}
+}
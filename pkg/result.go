@@ -11,7 +11,7 @@ import (
 // ChaosResultVerdict checks the chaos result verdict
 func ChaosResultVerdict(experimentsDetails *types.ExperimentDetails, clients environment.ClientSets) error {
 
-	if err = WaitForChaosResultCompletion(experimentsDetails, clients); err != nil {
+	if err := WaitForChaosResultCompletion(experimentsDetails, clients); err != nil {
 		return errors.Errorf("engine state check failed, err %v", err)
 	}
 
@@ -30,7 +30,7 @@ func ChaosResultVerdict(experimentsDetails *types.ExperimentDetails, clients env
 // ChaosEngineVerdict checks the chaosengine verdict
 func ChaosEngineVerdict(experimentsDetails *types.ExperimentDetails, clients environment.ClientSets) error {
 
-	if err = WaitForEngineCompletion(experimentsDetails, clients); err != nil {
+	if err := WaitForEngineCompletion(experimentsDetails, clients); err != nil {
 		return errors.Errorf("engine state check failed, err %v", err)
 	}
 
@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/infrastructure"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+	"k8s.io/klog"
+)
+
+// Job is a single experiment of a batch
+type Job struct {
+	ExperimentType workflow.ExperimentType
+	Details        types.ExperimentDetails // Copied by the run along with its slices, so that the jobs don't share any state
+	Options        Options
+}
+
+// BatchOptions tunes a batch of runs
+type BatchOptions struct {
+	MaxInFlight int      // Experiments running at the same time, 1 if not set
+	InfraIDs    []string // Connected infrastructures the jobs without an InfraID are spread over
+}
+
+// RunAll runs the jobs with at most MaxInFlight of them at the same time. The jobs without an InfraID are
+// spread over the InfraIDs, or over a single infrastructure which is set up for the batch and disconnected
+// once every job is done. The probes of the jobs are created once for the batch and deleted once every job
+// is done, since the jobs defining the same probe share it. The results and errors are in the order of the
+// jobs, a result is never nil.
+func RunAll(ctx context.Context, jobs []Job, opts BatchOptions) ([]*Result, []error) {
+	results := make([]*Result, len(jobs))
+	errs := make([]error, len(jobs))
+	failAll := func(err error) ([]*Result, []error) {
+		for i, job := range jobs {
+			results[i] = &Result{ExperimentType: job.ExperimentType}
+			errs[i] = err
+		}
+		return results, errs
+	}
+
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = 1
+	}
+
+	infraIDs := opts.InfraIDs
+	setupInfra := len(infraIDs) == 0 && needsInfra(jobs)
+	var sdkClient sdk.Client
+	if setupInfra || needsProbes(jobs) {
+		var err error
+		if sdkClient, err = newSDKClient(); err != nil {
+			return failAll(fmt.Errorf("unable to generate Litmus SDK client: %v", err))
+		}
+	}
+
+	if setupInfra {
+		infraID, teardown, err := setupBatchInfra(jobs[0].Details, sdkClient)
+		if err != nil {
+			return failAll(err)
+		}
+		defer teardown()
+		infraIDs = []string{infraID}
+	}
+
+	jobs = slices.Clone(jobs)
+	teardownProbes, err := setupBatchProbes(jobs, sdkClient)
+	if err != nil {
+		return failAll(err)
+	}
+	defer teardownProbes()
+
+	klog.Infof("[Batch]: Running %d experiments, at most %d at the same time", len(jobs), maxInFlight)
+	slots := make(chan struct{}, maxInFlight)
+	var wg sync.WaitGroup
+	next := 0
+	for i := range jobs {
+		job := jobs[i]
+		if job.Options.InfraID == "" && len(infraIDs) != 0 {
+			job.Options.InfraID = infraIDs[next%len(infraIDs)]
+			next++
+		}
+
+		// Wait for a slot, the jobs which haven't started by the time the context is cancelled are skipped
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case slots <- struct{}{}:
+			}
+		}
+		if ctx.Err() != nil {
+			results[i] = &Result{ExperimentType: job.ExperimentType, Cancelled: true}
+			errs[i] = fmt.Errorf("experiment %s was cancelled before its start: %v", job.ExperimentType, ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, job Job) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			job.Details.CreatedProbes = slices.Clone(job.Details.CreatedProbes)
+			job.Details.MustPassProbes = slices.Clone(job.Details.MustPassProbes)
+			job.Details.InlineProbePaths = slices.Clone(job.Details.InlineProbePaths)
+			results[i], errs[i] = runJob(ctx, job.ExperimentType, &job.Details, job.Options)
+		}(i, job)
+	}
+	wg.Wait()
+	return results, errs
+}
+
+// needsInfra checks if any job needs an infrastructure to be set up
+func needsInfra(jobs []Job) bool {
+	for _, job := range jobs {
+		if job.Options.InfraID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// needsProbes checks if any job creates a probe
+func needsProbes(jobs []Job) bool {
+	for _, job := range jobs {
+		if job.Details.CreateProbe {
+			return true
+		}
+	}
+	return false
+}
+
+// setupBatchInfra connects the infrastructure shared by the jobs of a batch and returns its teardown
+func setupBatchInfra(details types.ExperimentDetails, sdkClient sdk.Client) (string, func(), error) {
+	if err := setupInfrastructure(&details, sdkClient); err != nil {
+		return "", nil, err
+	}
+	teardown := func() {
+		klog.Info("[CleanUp]: Cleaning up the infrastructure of the batch")
+		if err := infrastructure.DisconnectInfrastructure(&details, sdkClient); err != nil {
+			klog.Errorf("[CleanUp]: failed to clean up infrastructure: %v", err)
+		}
+	}
+	return details.ConnectedInfraID, teardown, nil
+}
+
+// setupBatchProbes creates the probes of the jobs once per probe name, the first job defining a probe sets it up.
+// The jobs are updated to use the probes without creating or deleting them, the returned teardown deletes
+// the probes created for the batch once every job is done.
+func setupBatchProbes(jobs []Job, sdkClient sdk.Client) (func(), error) {
+	var created []*types.ExperimentDetails
+	probeIDs := map[string]string{}
+	for i := range jobs {
+		details := &jobs[i].Details
+		if !details.CreateProbe {
+			continue
+		}
+		definition, err := probe.FromExperimentDetails(details)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the probe of experiment %s: %v", jobs[i].ExperimentType, err)
+		}
+
+		if _, ok := probeIDs[definition.Name]; !ok {
+			klog.Infof("[Batch]: Setting up probe %s", definition.Name)
+			setupDetails := *details
+			setupDetails.CreatedProbes = nil
+			if err := createProbe(&setupDetails, sdkClient, setupDetails.LitmusProjectID); err != nil {
+				teardownBatchProbes(created, sdkClient)
+				return nil, fmt.Errorf("failed to create probe %s: %v", definition.Name, err)
+			}
+			if setupDetails.CreatedProbeID == "" {
+				teardownBatchProbes(created, sdkClient)
+				return nil, fmt.Errorf("probe creation failed: CreatedProbeID of %s is empty", definition.Name)
+			}
+			probeIDs[definition.Name] = setupDetails.CreatedProbeID
+			created = append(created, &setupDetails)
+		}
+		details.CreateProbe = false
+		details.CreatedProbeID = probeIDs[definition.Name]
+	}
+	return func() { teardownBatchProbes(created, sdkClient) }, nil
+}
+
+// teardownBatchProbes deletes the probes created for the batch, if LITMUS_DELETE_PROBES is set to true
+func teardownBatchProbes(created []*types.ExperimentDetails, sdkClient sdk.Client) {
+	for _, details := range created {
+		if len(details.CreatedProbes) == 0 {
+			continue
+		}
+		klog.Infof("[CleanUp]: Deleting the probes created for the batch: %s", strings.Join(details.CreatedProbes, ", "))
+		if err := deleteCreatedProbes(details, sdkClient); err != nil {
+			klog.Errorf("[CleanUp]: failed to delete the probes of the batch: %v", err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+)
+
+// fakeProbes is the probes of a ChaosCenter project, created and deleted like workflow.CreateProbe and workflow.DeleteCreatedProbes
+type fakeProbes struct {
+	mu      sync.Mutex
+	probes  map[string]bool
+	deletes int
+}
+
+func (f *fakeProbes) create(details *types.ExperimentDetails, _ sdk.Client, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.probes[details.ProbeName] {
+		f.probes[details.ProbeName] = true
+		details.CreatedProbes = append(details.CreatedProbes, details.ProbeName)
+	}
+	details.CreatedProbeID = details.ProbeName
+	return nil
+}
+
+func (f *fakeProbes) delete(details *types.ExperimentDetails, _ sdk.Client) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !details.DeleteProbes {
+		return nil
+	}
+	for _, name := range details.CreatedProbes {
+		delete(f.probes, name)
+		f.deletes++
+	}
+	details.CreatedProbes = nil
+	return nil
+}
+
+func (f *fakeProbes) exists(name string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.probes[name]
+}
+
+// stubProbes replaces the ChaosCenter calls of the runner with the fake for the test
+func stubProbes(t *testing.T, store *fakeProbes) {
+	t.Helper()
+	origSDKClient, origCreate, origDelete, origRun := newSDKClient, createProbe, deleteCreatedProbes, runJob
+	t.Cleanup(func() {
+		newSDKClient, createProbe, deleteCreatedProbes, runJob = origSDKClient, origCreate, origDelete, origRun
+	})
+	newSDKClient = func() (sdk.Client, error) { return nil, nil }
+	createProbe = store.create
+	deleteCreatedProbes = store.delete
+}
+
+func TestRunAllKeepsSharedProbeUntilEveryJobIsDone(t *testing.T) {
+	store := &fakeProbes{probes: map[string]bool{}}
+	stubProbes(t, store)
+
+	details := types.ExperimentDetails{
+		CreateProbe:       true,
+		DeleteProbes:      true,
+		ProbeName:         "check-app",
+		ProbeType:         "httpProbe",
+		ProbeURL:          "http://app.default.svc:8080",
+		ProbeResponseCode: "200",
+		ProbeTimeout:      "5s",
+		ProbeInterval:     "2s",
+	}
+	jobs := []Job{
+		{ExperimentType: workflow.ExperimentType("pod-delete"), Details: details, Options: Options{InfraID: "infra"}},
+		{ExperimentType: workflow.ExperimentType("pod-cpu-hog"), Details: details, Options: Options{InfraID: "infra"}},
+	}
+
+	// Both jobs set up their probe, then the first one to set it up finishes while the other one still uses it
+	var order sync.Mutex
+	setUp := 0
+	started := sync.WaitGroup{}
+	started.Add(len(jobs))
+	firstDone := make(chan struct{})
+	runJob = func(ctx context.Context, experimentType workflow.ExperimentType, experimentsDetails *types.ExperimentDetails, opts Options) (*Result, error) {
+		result := &Result{ExperimentType: experimentType}
+		order.Lock()
+		if err := setup(experimentsDetails, nil, false); err != nil {
+			order.Unlock()
+			started.Done()
+			return result, err
+		}
+		setUp++
+		first := setUp == 1
+		order.Unlock()
+		started.Done()
+		started.Wait()
+
+		if first {
+			defer close(firstDone)
+		} else {
+			<-firstDone
+			if !store.exists("check-app") {
+				return result, fmt.Errorf("probe check-app was deleted while %s was running", experimentType)
+			}
+		}
+		return result, cleanup(experimentsDetails, nil, false)
+	}
+
+	_, errs := RunAll(context.Background(), jobs, BatchOptions{MaxInFlight: 2})
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d failed: %v", i, err)
+		}
+	}
+	if store.exists("check-app") {
+		t.Errorf("expected probe check-app to be deleted once the batch is done")
+	}
+	if store.deletes != 1 {
+		t.Errorf("expected probe check-app to be deleted once, got %d deletions", store.deletes)
+	}
+}
+
+func TestRunAllCopiesTheSlicesOfTheJobs(t *testing.T) {
+	store := &fakeProbes{probes: map[string]bool{}}
+	stubProbes(t, store)
+
+	// The jobs share the backing arrays of the details, which every run writes to
+	details := types.ExperimentDetails{
+		CreatedProbes:    make([]string, 1, 2),
+		MustPassProbes:   []string{"check-app"},
+		InlineProbePaths: []string{"probes/check-app.yaml"},
+	}
+	jobs := []Job{
+		{ExperimentType: workflow.ExperimentType("pod-delete"), Details: details, Options: Options{InfraID: "infra"}},
+		{ExperimentType: workflow.ExperimentType("pod-cpu-hog"), Details: details, Options: Options{InfraID: "infra"}},
+	}
+	runJob = func(ctx context.Context, experimentType workflow.ExperimentType, experimentsDetails *types.ExperimentDetails, opts Options) (*Result, error) {
+		experimentsDetails.CreatedProbes[0] = string(experimentType)
+		experimentsDetails.MustPassProbes[0] = string(experimentType)
+		experimentsDetails.InlineProbePaths[0] = string(experimentType)
+		return &Result{ExperimentType: experimentType}, nil
+	}
+
+	_, errs := RunAll(context.Background(), jobs, BatchOptions{MaxInFlight: 2})
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("job %d failed: %v", i, err)
+		}
+	}
+	if details.CreatedProbes[0] != "" || details.MustPassProbes[0] != "check-app" || details.InlineProbePaths[0] != "probes/check-app.yaml" {
+		t.Errorf("expected the details of the jobs to be left untouched by the runs, got %+v", details)
+	}
+}
@@ -0,0 +1,98 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+)
+
+// ExitCodeCancelled is the exit code of a run cancelled by SIGINT or SIGTERM, so that
+// the CI can tell a cancelled run from a failed one
+const ExitCodeCancelled = 130
+
+// CancelGracePeriod is the time given to a cancelled run to stop the chaos and tear down,
+// to be used as the GracePeriod of the specs
+const CancelGracePeriod = 5 * time.Minute
+
+// stopEnginePatch stops the chaos of a ChaosEngine
+const stopEnginePatch = `{"spec":{"engineState":"stop"}}`
+
+// NotifyContext returns a context which is cancelled on SIGINT or SIGTERM, like the ones sent
+// by the CI when a job is cancelled or hits its deadline
+func NotifyContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
+
+// ExitIfCancelled exits with ExitCodeCancelled if the run was cancelled, the teardown
+// has already run by the time Run returns
+func ExitIfCancelled(result *Result) {
+	if result == nil || !result.Cancelled {
+		return
+	}
+	klog.Errorf("[Cancel]: Experiment %s was cancelled, exiting with code %d", result.ExperimentName, ExitCodeCancelled)
+	klog.Flush()
+	os.Exit(ExitCodeCancelled)
+}
+
+// stopRun stops the run of the cancelled experiment in ChaosCenter and patches its ChaosEngines
+// to stop, in case the chaos infrastructure doesn't get to it. Every step is attempted.
+func stopRun(experimentsDetails *types.ExperimentDetails, sdkClient sdk.Client, experimentID, experimentRunID string) error {
+	var failed []string
+
+	klog.Infof("[Cancel]: Stopping the run of experiment %s", experimentsDetails.ExperimentName)
+	client, err := newChaosCenterClient(experimentsDetails, sdkClient)
+	if err == nil {
+		err = client.StopExperimentRun(experimentID, experimentRunID)
+	}
+	if err != nil {
+		failed = append(failed, fmt.Sprintf("stop the experiment run: %v", err))
+	}
+
+	klog.Infof("[Cancel]: Patching the ChaosEngines of experiment %s to stop", experimentsDetails.ExperimentName)
+	if err := stopChaosEngines(experimentsDetails.ExperimentName); err != nil {
+		failed = append(failed, fmt.Sprintf("stop the chaos engines: %v", err))
+	}
+
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to stop the cancelled run:\n - %s", strings.Join(failed, "\n - "))
+	}
+	return nil
+}
+
+// stopEngines patches the engineState of the ChaosEngines created by the experiment to stop
+func stopEngines(experimentName string) error {
+	clients := environment.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		return fmt.Errorf("unable to get the kubeconfig: %v", err)
+	}
+
+	engines, err := clients.LitmusClient.ChaosEngines(metav1.NamespaceAll).List(metav1.ListOptions{
+		LabelSelector: "workflow_name=" + experimentName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list the chaos engines: %v", err)
+	}
+	var failed []string
+	for _, engine := range engines.Items {
+		if _, err := clients.LitmusClient.ChaosEngines(engine.Namespace).Patch(engine.Name, k8stypes.MergePatchType, []byte(stopEnginePatch)); err != nil {
+			failed = append(failed, fmt.Sprintf("%s/%s: %v", engine.Namespace, engine.Name, err))
+			continue
+		}
+		klog.Infof("[Cancel]: Stopped chaos engine %s/%s", engine.Namespace, engine.Name)
+	}
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to patch %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoscenter"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/tracker"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+)
+
+// fakeChaosCenter answers the GraphQL operations of a run, which stays Running until it is stopped
+type fakeChaosCenter struct {
+	mu      sync.Mutex
+	stopped map[string]interface{} // Variables of the stopExperimentRuns mutation, nil until it is called
+}
+
+func (f *fakeChaosCenter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var data string
+	switch request.OperationName {
+	case "saveChaosExperiment":
+		data = `{"saveChaosExperiment":"saved"}`
+	case "runChaosExperiment":
+		data = `{"runChaosExperiment":{"notifyID":"notify-1"}}`
+	case "getExperimentRun":
+		data = `{"getExperimentRun":{"experimentRunID":"run-1","notifyID":"notify-1","phase":"Running"}}`
+	case "stopExperimentRuns":
+		f.mu.Lock()
+		f.stopped = request.Variables
+		f.mu.Unlock()
+		data = `{"stopExperimentRuns":true}`
+	default:
+		http.Error(w, "unexpected operation "+request.OperationName, http.StatusBadRequest)
+		return
+	}
+	_, _ = w.Write([]byte(`{"data":` + data + `}`))
+}
+
+func TestRunStopsCancelledRun(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+	store := &fakeProbes{probes: map[string]bool{}}
+	stubProbes(t, store)
+
+	chaosCenter := &fakeChaosCenter{}
+	server := httptest.NewServer(chaosCenter)
+	defer server.Close()
+
+	origClient, origStopEngines := newChaosCenterClient, stopChaosEngines
+	t.Cleanup(func() {
+		newChaosCenterClient, stopChaosEngines = origClient, origStopEngines
+	})
+	newChaosCenterClient = func(experimentsDetails *types.ExperimentDetails, _ sdk.Client) (*chaoscenter.Client, error) {
+		return &chaoscenter.Client{Endpoint: server.URL, Token: "token", ProjectID: "project", HTTPClient: server.Client()}, nil
+	}
+	var stoppedEngines []string
+	stopChaosEngines = func(experimentName string) error {
+		stoppedEngines = append(stoppedEngines, experimentName)
+		return nil
+	}
+
+	// The job is cancelled once the run shows up
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan tracker.Event)
+	go func() {
+		for event := range events {
+			if event.Type == tracker.RunDiscovered {
+				cancel()
+			}
+		}
+	}()
+	defer close(events)
+
+	details := &types.ExperimentDetails{}
+	result, err := Run(ctx, workflow.PodDelete, details, Options{InfraID: "infra", Events: events})
+	if err == nil || !strings.Contains(err.Error(), "was cancelled") {
+		t.Errorf("expected the run to fail as cancelled, got: %v", err)
+	}
+	if !result.Cancelled || result.Passed {
+		t.Errorf("expected a cancelled run which didn't pass, got cancelled %t and passed %t", result.Cancelled, result.Passed)
+	}
+
+	chaosCenter.mu.Lock()
+	stopped := chaosCenter.stopped
+	chaosCenter.mu.Unlock()
+	if stopped == nil || stopped["experimentID"] != result.ExperimentID || stopped["experimentRunID"] != "run-1" {
+		t.Errorf("expected run run-1 of experiment %s to be stopped, got %v", result.ExperimentID, stopped)
+	}
+	if len(stoppedEngines) != 1 || stoppedEngines[0] != result.ExperimentName {
+		t.Errorf("expected the chaos engines of %s to be stopped, got %v", result.ExperimentName, stoppedEngines)
+	}
+}
@@ -0,0 +1,412 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/artifacts"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoscenter"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/infrastructure"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/podlogs"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/tracker"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/verdict"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
+	"k8s.io/klog"
+)
+
+// probeSetup serializes the probe setup of concurrent runs
+var probeSetup sync.Mutex
+
+// The calls to ChaosCenter and to the cluster made around the runs, replaced by the tests
+var (
+	newSDKClient         = environment.GenerateClientSetFromSDK
+	newChaosCenterClient = chaoscenter.NewClient
+	createProbe          = workflow.CreateProbe
+	deleteCreatedProbes  = workflow.DeleteCreatedProbes
+	stopChaosEngines     = stopEngines
+	runJob               = Run
+)
+
+const (
+	defaultRunDiscoveryRetries  = 20
+	defaultRunDiscoveryInterval = 5 * time.Second
+)
+
+// Check runs after the experiment run passed its verdict, like the recovery of the target nodes
+type Check func(ctx context.Context, experimentsDetails *types.ExperimentDetails) error
+
+// Options tunes a run, the zero value runs the fault with its defaults and the probe ENVs
+type Options struct {
+	Name                 string                     // Base name of the experiment, the fault type if empty
+	InfraID              string                     // Connected infrastructure to run on, set up and disconnected by the run if empty
+	Config               *workflow.ExperimentConfig // Configuration of the fault, the defaults along with the probe ENVs if nil
+	ExpectedPhase        string                     // Final phase the run needs to reach to pass, Completed if empty
+	RunDiscoveryRetries  int                        // Along with RunDiscoveryInterval, bounds the time for the started run to be picked up by the infrastructure
+	RunDiscoveryInterval time.Duration              // Along with RunDiscoveryRetries, bounds the time for the started run to be picked up by the infrastructure
+	Events               chan<- tracker.Event       // Receives the events of the run as they happen, it isn't closed by the run
+	PostChaosChecks      []Check                    // Checks run once the run passed its verdict
+}
+
+// Result is the outcome of a run
+type Result struct {
+	ExperimentType  workflow.ExperimentType
+	ExperimentID    string
+	ExperimentName  string
+	NotifyID        string // Returned when the run was started, it identifies the run until its run ID is known
+	ExperimentRunID string
+	InfraID         string
+	Phase           string // Final phase of the run, empty if it never reached one
+	ExpectedPhase   string
+	Passed          bool             // The run reached the expected phase and the post chaos checks passed
+	Cancelled       bool             // The context was cancelled, the chaos was stopped before the teardown
+	Verdict         *verdict.Verdict // Steps, faults and probes of the run, nil if the execution data couldn't be fetched
+	UnmetCriteria   []string         // Pass criteria the run didn't meet, like a resiliency score below the minimum
+	Timeline        tracker.Timeline // Phases of the run and of the nodes of its Argo workflow, as they changed
+	ArtifactPath    string           // Tarball of the artifacts of the failed run, empty if none were collected
+	StartedAt       time.Time
+	FinishedAt      time.Time
+}
+
+// Reason describes the outcome of the run from its verdict, down to the probes which failed, along with the unmet pass criteria
+func (r *Result) Reason() string {
+	reason := "no execution data available for the run"
+	if r.Verdict != nil {
+		reason = r.Verdict.String()
+	}
+	for _, criterion := range r.UnmetCriteria {
+		reason += "\n" + criterion
+	}
+	return reason
+}
+
+// Duration returns how long the run took
+func (r *Result) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// Run sets up the infrastructure and the probe, saves the experiment of the fault, starts a run of it,
+// waits for that run to reach a final phase and cleans up. The returned error covers the failures to run the experiment,
+// the verdict is reported by Result.Passed. The result is never nil. Once the context is cancelled the run
+// of the experiment and its ChaosEngines are stopped before the teardown and Result.Cancelled is set.
+func Run(ctx context.Context, experimentType workflow.ExperimentType, experimentsDetails *types.ExperimentDetails, opts Options) (result *Result, err error) {
+	result = &Result{
+		ExperimentType: experimentType,
+		ExpectedPhase:  opts.ExpectedPhase,
+		StartedAt:      time.Now(),
+	}
+	if result.ExpectedPhase == "" {
+		result.ExpectedPhase = "Completed"
+	}
+	defer func() {
+		result.FinishedAt = time.Now()
+		if len(result.Timeline) != 0 {
+			klog.Infof("[Timeline]: Experiment Run %s of %s:\n%s", result.ExperimentRunID, result.ExperimentName, result.Timeline.Table())
+		}
+	}()
+
+	klog.Infof("[PreChaos]: Initializing SDK client for the %s experiment", experimentType)
+	sdkClient, err := newSDKClient()
+	if err != nil {
+		return result, fmt.Errorf("unable to generate Litmus SDK client: %v", err)
+	}
+	client, err := newChaosCenterClient(experimentsDetails, sdkClient)
+	if err != nil {
+		return result, fmt.Errorf("unable to generate ChaosCenter client: %v", err)
+	}
+
+	// The cleanup runs whatever happens to the run, its failure is only reported if the run succeeded
+	defer func() {
+		if errCleanup := cleanup(experimentsDetails, sdkClient, opts.InfraID == ""); errCleanup != nil {
+			if err == nil {
+				err = errCleanup
+			} else {
+				klog.Errorf("[CleanUp]: %v", errCleanup)
+			}
+		}
+	}()
+
+	if opts.InfraID != "" {
+		experimentsDetails.ConnectedInfraID = opts.InfraID
+	}
+	if err := setup(experimentsDetails, sdkClient, opts.InfraID == ""); err != nil {
+		return result, err
+	}
+	result.InfraID = experimentsDetails.ConnectedInfraID
+
+	// Construct and save the experiment
+	config := opts.Config
+	if config == nil {
+		defaultConfig := workflow.GetDefaultExperimentConfig(experimentType)
+		workflow.ApplyProbeConfigFromEnv(&defaultConfig)
+		config = &defaultConfig
+	}
+	if workflow.TargetsNode(experimentType) {
+		// Litmus would pick any node of the cluster, the fault is pointed at the node of the application instead
+		resolved, err := workflow.WithTargetNode(experimentType, *config)
+		if err != nil {
+			return result, err
+		}
+		config = &resolved
+		// The node recovery checks the node the fault was pointed at
+		experimentsDetails.TargetNode, experimentsDetails.NodeLabel = config.TargetNode, config.NodeLabel
+	}
+	name := opts.Name
+	if name == "" {
+		name = string(experimentType)
+	}
+	result.ExperimentName = pkg.GenerateUniqueExperimentName(name)
+	result.ExperimentID = pkg.GenerateExperimentID()
+	experimentsDetails.ExperimentName = result.ExperimentName
+	if ctx.Err() != nil {
+		result.Cancelled = true
+		return result, fmt.Errorf("experiment %s was cancelled before its creation: %v", result.ExperimentName, ctx.Err())
+	}
+
+	klog.Infof("[SDK Prepare]: Constructing the chaos experiment request of %s", result.ExperimentName)
+	experimentRequest, err := workflow.ConstructExperimentRequest(experimentsDetails, result.ExperimentID, result.ExperimentName, experimentType, *config)
+	if err != nil {
+		return result, fmt.Errorf("failed to construct experiment request: %v", err)
+	}
+	saveResponse, err := client.SaveExperiment(*experimentRequest)
+	if err != nil {
+		return result, fmt.Errorf("failed to save experiment: %v", err)
+	}
+	klog.Infof("Saved experiment: %s", saveResponse)
+
+	// Start the run explicitly, the notify ID tells it apart from any other run of the experiment
+	if result.NotifyID, err = client.RunExperiment(result.ExperimentID); err != nil {
+		return result, fmt.Errorf("failed to run experiment %s: %v", result.ExperimentName, err)
+	}
+	klog.Infof("Started experiment run with notify ID: %s", result.NotifyID)
+
+	// Collect the evidence of a failed run before the teardown
+	var tailer *podlogs.Tailer
+	defer func() {
+		if experimentsDetails.ArtifactsDir != "" && !result.Passed && !result.Cancelled {
+			var logs map[podlogs.Container][]byte
+			if tailer != nil {
+				logs = tailer.Logs()
+			}
+			result.ArtifactPath = collectArtifacts(experimentsDetails, result, experimentRequest.Manifest, logs, err)
+		}
+	}()
+
+	// Stop the chaos of a cancelled run, the teardown follows once Run returns
+	cancelled := func() error {
+		result.Cancelled = true
+		if errStop := stopRun(experimentsDetails, sdkClient, result.ExperimentID, result.ExperimentRunID); errStop != nil {
+			klog.Errorf("[Cancel]: %v", errStop)
+		}
+		return fmt.Errorf("experiment %s was cancelled: %v", result.ExperimentName, ctx.Err())
+	}
+
+	// Follow the run until its final phase. The logs of its pods are tailed to be shown, or kept for the artifacts
+	// since the workflow deletes its pods once it completes.
+	if experimentsDetails.TailLogs || experimentsDetails.ArtifactsDir != "" {
+		logOpts := podlogs.Options{Keep: experimentsDetails.ArtifactsDir != ""}
+		if !experimentsDetails.TailLogs {
+			logOpts.Output = io.Discard
+		}
+		tailer = tailLogs(ctx, result.ExperimentName, logOpts)
+	}
+	var errTrack error
+	for event := range newTracker(experimentsDetails, client, opts).Track(ctx, result.ExperimentID, result.NotifyID) {
+		switch event.Type {
+		case tracker.RunDiscovered:
+			result.ExperimentRunID = event.ExperimentRunID
+		case tracker.RunFinished:
+			result.Phase = event.Phase
+		case tracker.TrackingFailed:
+			errTrack = event.Err
+		}
+		result.Timeline.Record(event)
+		if opts.Events != nil {
+			select {
+			case opts.Events <- event:
+			case <-ctx.Done():
+			}
+		}
+	}
+	if tailer != nil {
+		tailer.Stop()
+	}
+	if ctx.Err() != nil {
+		return result, cancelled()
+	}
+	if errTrack != nil {
+		if unfinished := result.Timeline.Unfinished(); len(unfinished) != 0 {
+			errTrack = fmt.Errorf("%v, stuck in %s", errTrack, strings.Join(unfinished, ", "))
+		}
+		return result, errTrack
+	}
+
+	// Verdict
+	klog.Infof("[SDK Verdict]: Experiment Run %s reached phase %s, expected %s", result.ExperimentRunID, result.Phase, result.ExpectedPhase)
+	if result.Verdict, err = getVerdict(client, result.ExperimentRunID); err != nil {
+		klog.Warningf("[SDK Verdict]: Unable to get the verdict of Experiment Run %s: %v", result.ExperimentRunID, err)
+		err = nil
+	} else {
+		klog.Infof("[SDK Verdict]: %s", result.Verdict)
+	}
+	result.UnmetCriteria = verdict.CriteriaFromExperimentDetails(experimentsDetails).Evaluate(result.Verdict)
+	for _, criterion := range result.UnmetCriteria {
+		klog.Warningf("[SDK Verdict]: Pass criteria not met: %s", criterion)
+	}
+	result.Passed = result.Phase == result.ExpectedPhase && len(result.UnmetCriteria) == 0
+	if !result.Passed {
+		return result, nil
+	}
+	for _, check := range opts.PostChaosChecks {
+		if err := check(ctx, experimentsDetails); err != nil {
+			result.Passed = false
+			return result, fmt.Errorf("post chaos check failed: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// newTracker returns the tracker of the run
+func newTracker(experimentsDetails *types.ExperimentDetails, client *chaoscenter.Client, opts Options) *tracker.Tracker {
+	retries, interval := opts.RunDiscoveryRetries, opts.RunDiscoveryInterval
+	if retries <= 0 {
+		retries = defaultRunDiscoveryRetries
+	}
+	if interval <= 0 {
+		interval = defaultRunDiscoveryInterval
+	}
+
+	return tracker.New(client, tracker.Options{
+		DiscoveryTimeout: time.Duration(retries) * interval,
+		Timeout:          time.Duration(experimentsDetails.ExperimentTimeout) * time.Minute,
+		MaxInterval:      time.Duration(experimentsDetails.ExperimentPollingInterval) * time.Second,
+	})
+}
+
+// tailLogs starts streaming the logs of the pods of the run, it returns nil if the kubeconfig isn't available
+func tailLogs(ctx context.Context, experimentName string, opts podlogs.Options) *podlogs.Tailer {
+	clients := environment.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		klog.Warningf("[Logs]: Unable to tail the logs of experiment %s: %v", experimentName, err)
+		return nil
+	}
+	tailer := podlogs.New(clients, experimentName, opts)
+	tailer.Start(ctx)
+	return tailer
+}
+
+// collectArtifacts bundles the artifacts of the failed run, along with the logs kept while it was tracked,
+// and returns the path of the tarball, empty if it couldn't be written
+func collectArtifacts(experimentsDetails *types.ExperimentDetails, result *Result, manifest string, logs map[podlogs.Container][]byte, errRun error) string {
+	clients := environment.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		klog.Warningf("[Artifacts]: Unable to collect the cluster artifacts of experiment %s: %v", result.ExperimentName, err)
+	}
+
+	summary := fmt.Sprintf("experiment: %s\nexperiment ID: %s\nnotify ID: %s\nexperiment run ID: %s\ninfra ID: %s\nphase: %s, expected %s\n",
+		result.ExperimentName, result.ExperimentID, result.NotifyID, result.ExperimentRunID, result.InfraID, result.Phase, result.ExpectedPhase)
+	if errRun != nil {
+		summary += fmt.Sprintf("error: %v\n", errRun)
+	}
+	summary += "\n" + result.Reason() + "\n"
+	if len(result.Timeline) != 0 {
+		summary += "\n" + result.Timeline.Table()
+	}
+
+	path, err := artifacts.Collect(clients, experimentsDetails, experimentsDetails.ArtifactsDir, artifacts.Run{
+		ExperimentName: result.ExperimentName,
+		Manifest:       manifest,
+		Summary:        summary,
+		Logs:           logs,
+	})
+	if err != nil {
+		klog.Errorf("[Artifacts]: %v", err)
+		return ""
+	}
+	return path
+}
+
+// getVerdict fetches the execution data of the run and parses its verdict
+func getVerdict(client *chaoscenter.Client, experimentRunID string) (*verdict.Verdict, error) {
+	run, err := client.GetExperimentRun(experimentRunID)
+	if err != nil {
+		return nil, err
+	}
+	return verdict.Parse(run)
+}
+
+// setup connects the infrastructure, unless the run is given one, and creates the probe, if configured to do so
+func setup(experimentsDetails *types.ExperimentDetails, sdkClient sdk.Client, setupInfra bool) error {
+	if setupInfra {
+		if err := setupInfrastructure(experimentsDetails, sdkClient); err != nil {
+			return err
+		}
+	}
+
+	if experimentsDetails.CreateProbe {
+		// Concurrent runs create or update the probe one at a time, since they usually share its name
+		probeSetup.Lock()
+		defer probeSetup.Unlock()
+
+		klog.Info("[PreChaos]: Setting up probe")
+		if err := createProbe(experimentsDetails, sdkClient, experimentsDetails.LitmusProjectID); err != nil {
+			return fmt.Errorf("failed to create probe: %v", err)
+		}
+		if experimentsDetails.CreatedProbeID == "" {
+			return fmt.Errorf("probe creation failed: CreatedProbeID is empty")
+		}
+	}
+	return nil
+}
+
+// setupInfrastructure connects the infrastructure configured by the ENVs
+func setupInfrastructure(experimentsDetails *types.ExperimentDetails, sdkClient sdk.Client) error {
+	klog.Info("[PreChaos]: Setting up infrastructure")
+	if err := infrastructure.SetupInfrastructure(experimentsDetails, sdkClient); err != nil {
+		return fmt.Errorf("failed to setup infrastructure: %v", err)
+	}
+	if experimentsDetails.ConnectedInfraID == "" {
+		return fmt.Errorf("setup failed: ConnectedInfraID is empty after connection attempt")
+	}
+	return nil
+}
+
+// cleanup deletes the probes created during the run and disconnects the infrastructure, if the run connected it
+func cleanup(experimentsDetails *types.ExperimentDetails, sdkClient sdk.Client, disconnectInfra bool) error {
+	klog.Info("[CleanUp]: Deleting the probes created during the run")
+	errProbes := deleteCreatedProbes(experimentsDetails, sdkClient)
+
+	if disconnectInfra {
+		klog.Info("[CleanUp]: Cleaning up infrastructure")
+		if err := infrastructure.DisconnectInfrastructure(experimentsDetails, sdkClient); err != nil {
+			return fmt.Errorf("failed to clean up infrastructure: %v", err)
+		}
+	}
+	if errProbes != nil {
+		return fmt.Errorf("failed to delete the probes: %v", errProbes)
+	}
+	return nil
+}
+
+// NodeRecoveryCheck waits for the target nodes of a node fault to recover and for the application pods to run again
+func NodeRecoveryCheck(ctx context.Context, experimentsDetails *types.ExperimentDetails) error {
+	clients := environment.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		return fmt.Errorf("unable to get the kubeconfig: %v", err)
+	}
+	klog.Info("[Post Chaos]: Verifying the recovery of the target nodes")
+	if err := pkg.WaitForNodeRecovery(experimentsDetails, clients); err != nil {
+		return fmt.Errorf("target nodes failed to recover: %v", err)
+	}
+	if err := pkg.PodStatusCheck(experimentsDetails, clients); err != nil {
+		return fmt.Errorf("application pods failed to get rescheduled: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoshub"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	"github.com/pkg/errors"
+)
+
+// File is the content of a chaos-ci.yaml scenario file
+type File struct {
+	Experiments []Experiment `json:"experiments"`
+	MaxInFlight int          `json:"maxInFlight,omitempty"` // Experiments running at the same time, one after the other if not set
+	Infras      []string     `json:"infras,omitempty"`      // IDs of the connected infrastructures the experiments are spread over
+}
+
+// Experiment describes a single chaos experiment of the scenario file
+type Experiment struct {
+	Name            string            `json:"name"`
+	Fault           string            `json:"fault"`
+	Target          Target            `json:"target,omitempty"`
+	Tunables        map[string]string `json:"tunables,omitempty"`        // Env of the fault, like TOTAL_CHAOS_DURATION
+	Probes          []Probe           `json:"probes,omitempty"`          // Existing ChaosCenter probes to attach
+	Timeout         int               `json:"timeout,omitempty"`         // Timeout in minutes for the experiment run
+	PollingInterval int               `json:"pollingInterval,omitempty"` // Interval in seconds to poll the run status
+	Criteria        Criteria          `json:"criteria,omitempty"`
+}
+
+// Target is the application under chaos
+type Target struct {
+	Namespace string `json:"namespace,omitempty"`
+	Label     string `json:"label,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+}
+
+// Probe references an existing ChaosCenter probe, or a probe definition which is inlined in the ChaosEngine
+type Probe struct {
+	Name       string `json:"name,omitempty"`
+	Mode       string `json:"mode,omitempty"`
+	Definition string `json:"definition,omitempty"` // Path of the probe definition, relative to the scenario file
+
+	inline *probe.Definition
+}
+
+// Criteria holds the pass criteria of an experiment run
+type Criteria struct {
+	Phase                     string   `json:"phase,omitempty"`                     // Expected final phase of the run, Completed by default
+	MinResiliencyScore        float64  `json:"minResiliencyScore,omitempty"`        // Minimum resiliency score of the run
+	MinProbeSuccessPercentage float64  `json:"minProbeSuccessPercentage,omitempty"` // Minimum probe success percentage of every fault
+	MustPassProbes            []string `json:"mustPassProbes,omitempty"`            // Names of the probes which need to pass
+}
+
+// Load reads and validates the scenario file at the given path
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the scenario file %s", path)
+	}
+
+	var file File
+	if err := yamlChe.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the scenario file %s", path)
+	}
+	if len(file.Experiments) == 0 {
+		return nil, errors.Errorf("no experiments found in the scenario file %s", path)
+	}
+	if file.MaxInFlight < 0 {
+		return nil, errors.Errorf("maxInFlight of the scenario file %s can't be negative", path)
+	}
+
+	hub := chaoshub.New(environment.Getenv("CHAOS_HUB_PATH", ""))
+	names := map[string]bool{}
+	for i, experiment := range file.Experiments {
+		if experiment.Name == "" {
+			return nil, errors.Errorf("experiment %d of the scenario file has no name", i+1)
+		}
+		if names[experiment.Name] {
+			return nil, errors.Errorf("experiment %s is defined more than once", experiment.Name)
+		}
+		names[experiment.Name] = true
+		if experiment.Fault == "" {
+			return nil, errors.Errorf("experiment %s has no fault", experiment.Name)
+		}
+		if _, err := hub.Get(experiment.Fault); err != nil {
+			return nil, errors.Wrapf(err, "experiment %s", experiment.Name)
+		}
+		if !inPercentRange(experiment.Criteria.MinResiliencyScore) || !inPercentRange(experiment.Criteria.MinProbeSuccessPercentage) {
+			return nil, errors.Errorf("experiment %s has a minimum resiliency score or probe success percentage outside of 0-100", experiment.Name)
+		}
+		for j := range experiment.Probes {
+			if err := file.Experiments[i].Probes[j].load(filepath.Dir(path)); err != nil {
+				return nil, errors.Wrapf(err, "experiment %s", experiment.Name)
+			}
+		}
+	}
+	return &file, nil
+}
+
+// load reads the probe definition, if the probe has one
+func (p *Probe) load(dir string) error {
+	if p.Definition == "" {
+		if p.Name == "" {
+			return errors.Errorf("probe without a name or a definition")
+		}
+		return nil
+	}
+
+	path := p.Definition
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	definition, err := probe.Load(path)
+	if err != nil {
+		return err
+	}
+	if p.Name != "" && p.Name != definition.Name {
+		return errors.Errorf("probe %s doesn't match the name %s of its definition", p.Name, definition.Name)
+	}
+	p.Name = definition.Name
+	if p.Mode != "" {
+		definition.Mode = p.Mode
+	}
+	p.inline = definition
+	return nil
+}
+
+// ExperimentType returns the type of the fault injected by the experiment
+func (e *Experiment) ExperimentType() workflow.ExperimentType {
+	return workflow.ExperimentType(e.Fault)
+}
+
+// ExperimentDetails returns the details fetched from the ENVs, overridden with the values set for the experiment
+func (e *Experiment) ExperimentDetails() types.ExperimentDetails {
+	details := types.ExperimentDetails{}
+	environment.GetENV(&details, e.Fault, e.Fault+"-engine")
+	e.applyTo(&details)
+	return details
+}
+
+// applyTo overrides the details with the values set for the experiment
+func (e *Experiment) applyTo(details *types.ExperimentDetails) {
+	if e.Target.Namespace != "" {
+		details.AppNS = e.Target.Namespace
+	}
+	if e.Target.Label != "" {
+		details.AppLabel = e.Target.Label
+	}
+	if e.Target.Kind != "" {
+		details.AppKind = e.Target.Kind
+	}
+	if e.Timeout != 0 {
+		details.ExperimentTimeout = e.Timeout
+	}
+	if e.PollingInterval != 0 {
+		details.ExperimentPollingInterval = e.PollingInterval
+	}
+	if e.Criteria.MinResiliencyScore != 0 {
+		details.MinResiliencyScore = e.Criteria.MinResiliencyScore
+	}
+	if e.Criteria.MinProbeSuccessPercentage != 0 {
+		details.MinProbeSuccessPercentage = e.Criteria.MinProbeSuccessPercentage
+	}
+	if len(e.Criteria.MustPassProbes) != 0 {
+		details.MustPassProbes = e.Criteria.MustPassProbes
+	}
+	// The probes of the file replace the ones configured by the ENVs
+	if len(e.Probes) != 0 {
+		details.InlineProbePaths = nil
+		details.CreateProbe = false
+		details.ProbeDefinitionPath = ""
+	}
+	// The node recovery checks the node and the taints the fault was pointed at
+	if value, ok := e.Tunables["TARGET_NODE"]; ok {
+		details.TargetNode = value
+	}
+	if value, ok := e.Tunables["NODE_LABEL"]; ok {
+		details.NodeLabel = value
+	}
+	if value, ok := e.Tunables["TAINTS"]; ok {
+		details.Taints = value
+	}
+}
+
+// ExperimentConfig returns the configuration of the fault, starting from its defaults
+func (e *Experiment) ExperimentConfig() workflow.ExperimentConfig {
+	config := workflow.GetDefaultExperimentConfig(e.ExperimentType())
+	if e.Target.Namespace != "" {
+		config.AppNamespace = e.Target.Namespace
+	}
+	if e.Target.Label != "" {
+		config.AppLabel = e.Target.Label
+	}
+	if e.Target.Kind != "" {
+		config.AppKind = e.Target.Kind
+	}
+
+	if len(e.Tunables) != 0 {
+		config.Env = map[string]string{}
+		for name, value := range e.Tunables {
+			config.Env[name] = value
+		}
+	}
+	// The node tunables are also typed, so that runner.Run doesn't look up the node of the application
+	if value, ok := e.Tunables["TARGET_NODE"]; ok {
+		config.TargetNode = value
+	}
+	if value, ok := e.Tunables["NODE_LABEL"]; ok {
+		config.NodeLabel = value
+	}
+	if value, ok := e.Tunables["TAINTS"]; ok {
+		config.Taints = value
+	}
+
+	// Without probes in the file, the probe configured by the ENVs is used
+	if len(e.Probes) == 0 {
+		workflow.ApplyProbeConfigFromEnv(&config)
+	} else {
+		config.UseExistingProbe = true
+		config.ProbeName = ""
+		config.ProbeMode = ""
+		config.Probes = nil
+		for _, p := range e.Probes {
+			if p.inline != nil {
+				config.InlineProbes = append(config.InlineProbes, *p.inline)
+				continue
+			}
+			config.Probes = append(config.Probes, workflow.ProbeRef{Name: p.Name, Mode: p.Mode})
+		}
+	}
+	return config
+}
+
+// inPercentRange checks if the value is a valid percentage
+func inPercentRange(value float64) bool {
+	return value >= 0 && value <= 100
+}
+
+// ExpectedPhase returns the final phase the experiment run needs to reach to pass
+func (e *Experiment) ExpectedPhase() string {
+	if e.Criteria.Phase == "" {
+		return "Completed"
+	}
+	return e.Criteria.Phase
+}
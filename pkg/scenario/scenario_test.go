@@ -0,0 +1,233 @@
+package scenario
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const checkAppProbe = `name: check-app
+type: httpProbe
+runProperties:
+  probeTimeout: 5s
+  interval: 2s
+httpProbe/inputs:
+  url: http://app.default.svc:8080
+  method:
+    get:
+      criteria: ==
+      responseCode: "200"
+`
+
+// writeScenario writes the scenario file and the probe definitions next to it, and returns the path of the scenario file
+func writeScenario(t *testing.T, scenario string, probes map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, definition := range probes {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(definition), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(dir, "chaos-ci.yaml")
+	if err := os.WriteFile(path, []byte(scenario), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeScenario(t, `maxInFlight: 2
+experiments:
+- name: delete-app
+  fault: pod-delete
+  target:
+    namespace: shop
+    label: app=cart
+  probes:
+  - definition: probes/check-app.yaml
+    mode: Continuous
+  criteria:
+    minResiliencyScore: 80
+- name: drain-node
+  fault: node-drain
+  tunables:
+    TARGET_NODE: worker-1
+`, map[string]string{"probes/check-app.yaml": checkAppProbe})
+
+	file, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load the scenario file: %v", err)
+	}
+	if file.MaxInFlight != 2 || len(file.Experiments) != 2 {
+		t.Fatalf("expected 2 experiments with maxInFlight 2, got %d with maxInFlight %d", len(file.Experiments), file.MaxInFlight)
+	}
+	probe := file.Experiments[0].Probes[0]
+	if probe.Name != "check-app" || probe.inline == nil || probe.inline.Mode != "Continuous" {
+		t.Errorf("expected the probe definition check-app in Continuous mode, got %+v", probe)
+	}
+}
+
+func TestLoadRejectsInvalidScenarios(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario string
+		wantErr  string
+	}{
+		{
+			name: "unknown fault",
+			scenario: `experiments:
+- name: delete-app
+  fault: pod-delete-typo
+`,
+			wantErr: "experiment delete-app: fault pod-delete-typo not found in the chaoshub",
+		},
+		{
+			name: "missing probe file",
+			scenario: `experiments:
+- name: delete-app
+  fault: pod-delete
+  probes:
+  - definition: probes/missing.yaml
+`,
+			wantErr: "failed to read the probe definition",
+		},
+		{
+			name: "probe name not matching its definition",
+			scenario: `experiments:
+- name: delete-app
+  fault: pod-delete
+  probes:
+  - name: other-probe
+    definition: check-app.yaml
+`,
+			wantErr: "probe other-probe doesn't match the name check-app of its definition",
+		},
+		{
+			name: "duplicate experiment",
+			scenario: `experiments:
+- name: delete-app
+  fault: pod-delete
+- name: delete-app
+  fault: pod-cpu-hog
+`,
+			wantErr: "experiment delete-app is defined more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeScenario(t, tt.scenario, map[string]string{"check-app.yaml": checkAppProbe})
+
+			_, err := Load(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExperimentOverridesEnv(t *testing.T) {
+	t.Setenv("APP_NS", "from-env")
+	t.Setenv("APP_LABEL", "app=from-env")
+	t.Setenv("EXPERIMENT_TIMEOUT", "8")
+	t.Setenv("LITMUS_INLINE_PROBES", "probes/from-env.yaml")
+	t.Setenv("LITMUS_CREATE_PROBE", "true")
+	t.Setenv("LITMUS_PROBE_NAME", "http-probe")
+	t.Setenv("LITMUS_PROBE_DEFINITION", "probes/http-probe.yaml")
+
+	path := writeScenario(t, `experiments:
+- name: delete-app
+  fault: pod-delete
+  target:
+    namespace: shop
+  timeout: 20
+  tunables:
+    TOTAL_CHAOS_DURATION: "60"
+  probes:
+  - definition: check-app.yaml
+  - name: http-probe
+    mode: Edge
+  criteria:
+    mustPassProbes: [check-app]
+`, map[string]string{"check-app.yaml": checkAppProbe})
+	file, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load the scenario file: %v", err)
+	}
+	experiment := file.Experiments[0]
+
+	details := experiment.ExperimentDetails()
+	if details.AppNS != "shop" || details.AppLabel != "app=from-env" {
+		t.Errorf("expected the target namespace of the scenario and the label of the ENVs, got %s and %s", details.AppNS, details.AppLabel)
+	}
+	if details.ExperimentTimeout != 20 {
+		t.Errorf("expected the timeout of the scenario, got %d", details.ExperimentTimeout)
+	}
+	if details.InlineProbePaths != nil {
+		t.Errorf("expected the probes of the scenario to replace the ones of the ENVs, got %v", details.InlineProbePaths)
+	}
+	// The probe of the ENVs would replace the existing http-probe the scenario refers to
+	if details.CreateProbe || details.ProbeDefinitionPath != "" {
+		t.Errorf("expected the probe of the ENVs not to be created, got CreateProbe %v and definition %q", details.CreateProbe, details.ProbeDefinitionPath)
+	}
+	if len(details.MustPassProbes) != 1 || details.MustPassProbes[0] != "check-app" {
+		t.Errorf("expected the must pass probes of the scenario, got %v", details.MustPassProbes)
+	}
+
+	config := experiment.ExperimentConfig()
+	if config.AppNamespace != "shop" {
+		t.Errorf("expected the target namespace of the scenario in the fault config, got %s", config.AppNamespace)
+	}
+	if config.Env["TOTAL_CHAOS_DURATION"] != "60" {
+		t.Errorf("expected the tunables of the scenario in the fault env, got %v", config.Env)
+	}
+	if len(config.InlineProbes) != 1 || config.InlineProbes[0].Name != "check-app" {
+		t.Errorf("expected the probe definition of the scenario to be inlined, got %+v", config.InlineProbes)
+	}
+	if len(config.Probes) != 1 || config.Probes[0].Name != "http-probe" || config.Probes[0].Mode != "Edge" {
+		t.Errorf("expected the existing probe of the scenario to be attached, got %+v", config.Probes)
+	}
+}
+
+func TestExperimentNodeTunables(t *testing.T) {
+	t.Setenv("TARGET_NODE", "")
+	t.Setenv("NODE_LABEL", "")
+	t.Setenv("TAINTS", "")
+
+	path := writeScenario(t, `experiments:
+- name: taint-node
+  fault: node-taint
+  tunables:
+    TARGET_NODE: worker-2
+    TAINTS: chaos=true:NoSchedule
+- name: drain-node
+  fault: node-drain
+  tunables:
+    NODE_LABEL: pool=spot
+`, nil)
+	file, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load the scenario file: %v", err)
+	}
+
+	taint := file.Experiments[0]
+	config := taint.ExperimentConfig()
+	if config.TargetNode != "worker-2" || config.Taints != "chaos=true:NoSchedule" {
+		t.Errorf("expected the target node and the taints of the scenario in the fault config, got %s and %s", config.TargetNode, config.Taints)
+	}
+	details := taint.ExperimentDetails()
+	if details.TargetNode != "worker-2" || details.Taints != "chaos=true:NoSchedule" {
+		t.Errorf("expected the node recovery to check the node and the taints of the scenario, got %s and %s", details.TargetNode, details.Taints)
+	}
+
+	drain := file.Experiments[1]
+	if config := drain.ExperimentConfig(); config.NodeLabel != "pool=spot" || config.TargetNode != "" {
+		t.Errorf("expected the node label of the scenario in the fault config, got %s and target node %s", config.NodeLabel, config.TargetNode)
+	}
+	if details := drain.ExperimentDetails(); details.NodeLabel != "pool=spot" {
+		t.Errorf("expected the node recovery to check the node label of the scenario, got %s", details.NodeLabel)
+	}
+}
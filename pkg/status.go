@@ -16,7 +16,7 @@ import (
 func RunnerPodStatus(experimentsDetails *types.ExperimentDetails, runnerNamespace string, clients environment.ClientSets) error {
 
 	//Fetching the runner pod and Checking if it gets in Running state or not
-	if err = CheckRunnerPodCreation(experimentsDetails.EngineName, runnerNamespace, clients); err != nil {
+	if err := CheckRunnerPodCreation(experimentsDetails.EngineName, runnerNamespace, clients); err != nil {
 		return errors.Errorf("fail to get the runner pod, due to %v", err)
 	}
 	runner, err := clients.KubeClient.CoreV1().Pods(runnerNamespace).Get(experimentsDetails.EngineName+"-runner", metav1.GetOptions{})
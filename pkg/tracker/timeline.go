@@ -0,0 +1,80 @@
+package tracker
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// Entry is a transition of the run, or of a node of its Argo workflow, as observed by the tracker
+type Entry struct {
+	Time     time.Time
+	Event    EventType
+	Node     string // Empty for the transitions of the run
+	NodeType string
+	Phase    string
+}
+
+// Timeline is the transitions of a run in the order they were observed
+type Timeline []Entry
+
+// Record adds the transition of the event to the timeline
+func (tl *Timeline) Record(event Event) {
+	*tl = append(*tl, Entry{
+		Time:     event.Time,
+		Event:    event.Type,
+		Node:     event.Node,
+		NodeType: event.NodeType,
+		Phase:    event.Phase,
+	})
+}
+
+// groupNodeTypes are the types of the nodes which only group the steps of the Argo workflow
+var groupNodeTypes = []string{"Steps", "StepGroup", "DAG", "TaskGroup"}
+
+// Unfinished returns the steps which were still pending or running at the last transition,
+// like the step a run which timed out got stuck in
+func (tl Timeline) Unfinished() []string {
+	phases := map[string]string{}
+	var nodes []string
+	for _, entry := range tl {
+		if entry.Node == "" || slices.Contains(groupNodeTypes, entry.NodeType) {
+			continue
+		}
+		if _, ok := phases[entry.Node]; !ok {
+			nodes = append(nodes, entry.Node)
+		}
+		phases[entry.Node] = entry.Phase
+	}
+
+	var unfinished []string
+	for _, node := range nodes {
+		if phases[node] == "Pending" || phases[node] == "Running" {
+			unfinished = append(unfinished, node)
+		}
+	}
+	return unfinished
+}
+
+// Table returns the timeline as a table, with the time elapsed since the first transition
+func (tl Timeline) Table() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tELAPSED\tEVENT\tNODE\tTYPE\tPHASE")
+	for _, entry := range tl {
+		elapsed := entry.Time.Sub(tl[0].Time).Round(time.Second)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.Time.Format("15:04:05"), elapsed, entry.Event, orDash(entry.Node), orDash(entry.NodeType), orDash(entry.Phase))
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// orDash returns the value, or a dash for the empty cells of the table
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
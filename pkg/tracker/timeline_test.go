@@ -0,0 +1,91 @@
+package tracker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timelineOf records the events, one second apart, in a timeline
+func timelineOf(events ...Event) Timeline {
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	var tl Timeline
+	for i, event := range events {
+		event.Time = start.Add(time.Duration(i) * time.Second)
+		tl.Record(event)
+	}
+	return tl
+}
+
+func TestTimelineUnfinished(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []Event
+		want   []string
+	}{
+		{
+			name: "stuck in the fault step",
+			events: []Event{
+				{Type: RunDiscovered, Phase: "Running"},
+				{Type: NodeChanged, Node: "pod-delete-engine", NodeType: "Steps", Phase: "Running"},
+				{Type: NodeChanged, Node: "[0]", NodeType: "StepGroup", Phase: "Running"},
+				{Type: NodeChanged, Node: "install-chaos-faults", NodeType: "Pod", Phase: "Running"},
+				{Type: NodeChanged, Node: "install-chaos-faults", NodeType: "Pod", Phase: "Succeeded"},
+				{Type: NodeChanged, Node: "pod-delete-ce5", NodeType: "ChaosEngine", Phase: "Pending"},
+				{Type: NodeChanged, Node: "pod-delete-ce5", NodeType: "ChaosEngine", Phase: "Running"},
+				{Type: TrackingFailed, Phase: "Running"},
+			},
+			want: []string{"pod-delete-ce5"},
+		},
+		{
+			name: "parallel faults in order of appearance",
+			events: []Event{
+				{Type: NodeChanged, Node: "pod-cpu-hog-ce5", NodeType: "ChaosEngine", Phase: "Running"},
+				{Type: NodeChanged, Node: "pod-delete-ce5-2", NodeType: "ChaosEngine", Phase: "Pending"},
+				{Type: NodeChanged, Node: "[1]", NodeType: "StepGroup", Phase: "Running"},
+			},
+			want: []string{"pod-cpu-hog-ce5", "pod-delete-ce5-2"},
+		},
+		{
+			name: "finished run",
+			events: []Event{
+				{Type: NodeChanged, Node: "pod-delete-ce5", NodeType: "ChaosEngine", Phase: "Running"},
+				{Type: NodeChanged, Node: "pod-delete-ce5", NodeType: "ChaosEngine", Phase: "Failed"},
+				{Type: NodeChanged, Node: "pod-delete-engine", NodeType: "Steps", Phase: "Running"},
+				{Type: RunFinished, Phase: "Error"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := timelineOf(tt.events...).Unfinished(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected the unfinished steps %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTimelineTable(t *testing.T) {
+	tl := timelineOf(
+		Event{Type: RunDiscovered, Phase: "Running"},
+		Event{Type: NodeChanged, Node: "install-chaos-faults", NodeType: "Pod", Phase: "Running"},
+		Event{Type: RunFinished},
+	)
+
+	lines := strings.Split(strings.TrimSuffix(tl.Table(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a header and 3 rows, got:\n%s", tl.Table())
+	}
+	want := [][]string{
+		{"TIME", "ELAPSED", "EVENT", "NODE", "TYPE", "PHASE"},
+		{"10:00:00", "0s", "RunDiscovered", "-", "-", "Running"},
+		{"10:00:01", "1s", "NodeChanged", "install-chaos-faults", "Pod", "Running"},
+		{"10:00:02", "2s", "RunFinished", "-", "-", "-"},
+	}
+	for i, line := range lines {
+		if got := strings.Fields(line); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("expected row %d to be %v, got %v", i, want[i], got)
+		}
+	}
+}
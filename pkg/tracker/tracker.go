@@ -0,0 +1,243 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoscenter"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/verdict"
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"k8s.io/klog"
+)
+
+// FinalPhases are the phases an experiment run ends in
+var FinalPhases = []string{"Completed", "Completed_With_Error", "Failed", "Error", "Stopped", "Skipped", "Aborted", "Timeout", "Terminated"}
+
+// EventType is the kind of a tracking event
+type EventType string
+
+const (
+	RunDiscovered  EventType = "RunDiscovered"  // The run of the experiment showed up
+	PhaseChanged   EventType = "PhaseChanged"   // The run moved to a phase which isn't final
+	NodeChanged    EventType = "NodeChanged"    // A node of the Argo workflow of the run moved to a phase
+	RunFinished    EventType = "RunFinished"    // The run reached a final phase, the event is the last one
+	TrackingFailed EventType = "TrackingFailed" // The run couldn't be tracked, like after a timeout, the event is the last one
+)
+
+// Event is a change of the run of the tracked experiment
+type Event struct {
+	Type            EventType
+	ExperimentRunID string
+	Phase           string // Phase of the run, or of the node for NodeChanged
+	Node            string // Name of the node for NodeChanged, like install-chaos-faults
+	NodeType        string // Type of the node for NodeChanged, like ChaosEngine
+	Time            time.Time
+	Err             error // Set for TrackingFailed
+}
+
+// Options tunes the tracking
+type Options struct {
+	DiscoveryTimeout time.Duration // Time for the run to show up, 100 seconds if not set
+	Timeout          time.Duration // Time for the run to reach a final phase once it showed up, 8 minutes if not set
+	MinInterval      time.Duration // First polling delay, used again after every change, 2 seconds if not set
+	MaxInterval      time.Duration // Longest polling delay the backoff grows to, 30 seconds if not set and MinInterval if below it
+}
+
+// Tracker follows a run of an experiment by polling ChaosCenter with an exponential backoff.
+// The run is the one started with the notify ID, other runs of the experiment are ignored.
+type Tracker struct {
+	client *chaoscenter.Client
+	opts   Options
+}
+
+// New returns a tracker with the defaults set for the options which aren't
+func New(client *chaoscenter.Client, opts Options) *Tracker {
+	if opts.DiscoveryTimeout <= 0 {
+		opts.DiscoveryTimeout = 100 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 8 * time.Minute
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = 2 * time.Second
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = 30 * time.Second
+	}
+	if opts.MaxInterval < opts.MinInterval {
+		opts.MaxInterval = opts.MinInterval
+	}
+	return &Tracker{client: client, opts: opts}
+}
+
+// state is the tracked run
+type state struct {
+	experimentID    string
+	notifyID        string
+	experimentRunID string
+	phase           string
+	nodes           map[string]string // Phases of the nodes of the run, by name
+	discovery       *time.Timer
+	timeout         *time.Timer   // Started once the run is discovered, so that the discovery doesn't count against it
+	runTimeout      time.Duration // Duration of the timeout
+	events          chan<- Event
+}
+
+// Track returns the events of the run of the experiment started with the notify ID. The channel is closed
+// after a RunFinished or TrackingFailed event, or once the context is cancelled.
+func (t *Tracker) Track(ctx context.Context, experimentID, notifyID string) <-chan Event {
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+		st := &state{
+			experimentID: experimentID,
+			notifyID:     notifyID,
+			nodes:        map[string]string{},
+			discovery:    time.NewTimer(t.opts.DiscoveryTimeout),
+			runTimeout:   t.opts.Timeout,
+			events:       events,
+		}
+		defer st.discovery.Stop()
+		defer func() {
+			if st.timeout != nil {
+				st.timeout.Stop()
+			}
+		}()
+
+		t.poll(ctx, st)
+	}()
+	return events
+}
+
+// emit sends the event, it returns false if the context was cancelled
+func (st *state) emit(ctx context.Context, event Event) bool {
+	event.Time = time.Now()
+	select {
+	case st.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// update records the run, its phase and the phases of its nodes, and emits the matching events.
+// It returns whether anything changed, and true once the tracking is done.
+func (st *state) update(ctx context.Context, run *models.ExperimentRun) (changed, done bool) {
+	experimentRunID, phase := run.ExperimentRunID, string(run.Phase)
+	if st.experimentRunID == "" {
+		if experimentRunID == "" {
+			return false, false
+		}
+		st.experimentRunID = experimentRunID
+		st.discovery.Stop()
+		st.timeout = time.NewTimer(st.runTimeout)
+		klog.Infof("[Tracker]: Found experiment run ID: %s", experimentRunID)
+		if !st.emit(ctx, Event{Type: RunDiscovered, ExperimentRunID: experimentRunID, Phase: phase}) {
+			return true, true
+		}
+		changed = true
+	}
+	if experimentRunID != st.experimentRunID {
+		return changed, false
+	}
+
+	// The nodes go first, so that the last steps of the run are in before it finishes
+	for _, node := range st.nodeChanges(run) {
+		changed = true
+		klog.Infof("[Tracker]: Experiment Run %s step %s: %s", experimentRunID, node.Name, node.Phase)
+		if !st.emit(ctx, Event{Type: NodeChanged, ExperimentRunID: experimentRunID, Phase: node.Phase, Node: node.Name, NodeType: node.Type}) {
+			return true, true
+		}
+	}
+
+	if phase == "" || phase == st.phase {
+		return changed, false
+	}
+	st.phase = phase
+	klog.Infof("[Tracker]: Experiment Run %s current phase: %s", experimentRunID, phase)
+	if slices.Contains(FinalPhases, phase) {
+		st.emit(ctx, Event{Type: RunFinished, ExperimentRunID: experimentRunID, Phase: phase})
+		return true, true
+	}
+	return true, !st.emit(ctx, Event{Type: PhaseChanged, ExperimentRunID: experimentRunID, Phase: phase})
+}
+
+// nodeChanges records the phases of the nodes of the run and returns the nodes whose phase changed,
+// in the order they started
+func (st *state) nodeChanges(run *models.ExperimentRun) []verdict.Node {
+	if run.ExecutionData == "" {
+		return nil
+	}
+	var data verdict.ExecutionData
+	if err := json.Unmarshal([]byte(run.ExecutionData), &data); err != nil {
+		klog.Warningf("[Tracker]: Unable to parse the execution data of experiment run %s: %v", run.ExperimentRunID, err)
+		return nil
+	}
+
+	var changes []verdict.Node
+	for _, node := range data.SortedNodes() {
+		if node.Phase == "" || st.nodes[node.Name] == node.Phase {
+			continue
+		}
+		st.nodes[node.Name] = node.Phase
+		changes = append(changes, node)
+	}
+	return changes
+}
+
+// timedOut returns the channel of the timeout, nil until the run is discovered so that it never fires before
+func (st *state) timedOut() <-chan time.Time {
+	if st.timeout == nil {
+		return nil
+	}
+	return st.timeout.C
+}
+
+// expired emits the TrackingFailed event of a timeout
+func (t *Tracker) expired(ctx context.Context, st *state, discovery bool) {
+	err := fmt.Errorf("timed out waiting for experiment run %s to complete after %v", st.experimentRunID, t.opts.Timeout)
+	if discovery {
+		err = fmt.Errorf("run %s of experiment %s not picked up by the infrastructure after %v", st.notifyID, st.experimentID, t.opts.DiscoveryTimeout)
+	}
+	st.emit(ctx, Event{Type: TrackingFailed, ExperimentRunID: st.experimentRunID, Phase: st.phase, Err: err})
+}
+
+// poll follows the run by polling it, the delay between the polls doubles up to MaxInterval
+// as long as nothing changes, and starts over from MinInterval after every change
+func (t *Tracker) poll(ctx context.Context, st *state) {
+	klog.Info("[Tracker]: Polling for the experiment run")
+	interval := t.opts.MinInterval
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-st.discovery.C:
+			t.expired(ctx, st, true)
+			return
+		case <-st.timedOut():
+			t.expired(ctx, st, false)
+			return
+		case <-time.After(interval):
+		}
+
+		changed := false
+		run, err := t.client.GetExperimentRunByNotifyID(st.notifyID)
+		if err != nil {
+			klog.Warningf("[Tracker]: Error fetching the experiment run: %v", err)
+		} else {
+			var done bool
+			if changed, done = st.update(ctx, run); done {
+				return
+			}
+		}
+
+		if changed {
+			interval = t.opts.MinInterval
+		} else {
+			interval = min(interval*2, t.opts.MaxInterval)
+		}
+	}
+}
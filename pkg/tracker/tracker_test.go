@@ -0,0 +1,56 @@
+package tracker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+func TestNewClampsMaxInterval(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            Options
+		wantMinInterval time.Duration
+		wantMaxInterval time.Duration
+	}{
+		{name: "defaults", opts: Options{}, wantMinInterval: 2 * time.Second, wantMaxInterval: 30 * time.Second},
+		{name: "max below min", opts: Options{MinInterval: 10 * time.Second, MaxInterval: 5 * time.Second}, wantMinInterval: 10 * time.Second, wantMaxInterval: 10 * time.Second},
+		{name: "max unset with min above its default", opts: Options{MinInterval: time.Minute}, wantMinInterval: time.Minute, wantMaxInterval: time.Minute},
+		{name: "max above min", opts: Options{MinInterval: time.Second, MaxInterval: 5 * time.Second}, wantMinInterval: time.Second, wantMaxInterval: 5 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := New(nil, tt.opts).opts
+			if opts.MinInterval != tt.wantMinInterval || opts.MaxInterval != tt.wantMaxInterval {
+				t.Errorf("expected intervals %v to %v, got %v to %v", tt.wantMinInterval, tt.wantMaxInterval, opts.MinInterval, opts.MaxInterval)
+			}
+		})
+	}
+}
+
+func TestTimeoutStartsOnDiscovery(t *testing.T) {
+	events := make(chan Event, 1)
+	st := &state{
+		experimentID: "experiment",
+		notifyID:     "notify",
+		nodes:        map[string]string{},
+		discovery:    time.NewTimer(time.Minute),
+		runTimeout:   time.Minute,
+		events:       events,
+	}
+	defer st.discovery.Stop()
+
+	if st.timedOut() != nil {
+		t.Fatalf("expected the timeout not to run before the run is discovered")
+	}
+	st.update(context.Background(), &models.ExperimentRun{ExperimentRunID: "run"})
+	if st.timedOut() == nil {
+		t.Fatalf("expected the timeout to run once the run is discovered")
+	}
+	st.timeout.Stop()
+	if event := <-events; event.Type != RunDiscovered {
+		t.Errorf("expected a %s event, got %s", RunDiscovered, event.Type)
+	}
+}
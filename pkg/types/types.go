@@ -51,6 +51,12 @@ type ExperimentDetails struct {
 	ExperimentTimeout                  int
 	ExperimentPollingInterval          int
 
+	// Node fault related fields
+	TargetNode          string // Name of the target nodes, comma separated
+	NodeLabel           string // Label of the target nodes, used if TargetNode is empty
+	Taints              string // Taints applied by the node-taint fault
+	NodeRecoveryTimeout int    // Timeout in seconds for the target nodes to recover after the chaos
+
 	// V3 SDK Related Fields
 	InstallLitmusFlag  bool
 	ConnectInfraFlag   bool
@@ -94,4 +100,41 @@ type ExperimentDetails struct {
 	ProbeAttempts     int    // Number of attempts for probe
 	ProbeResponseCode string // Expected HTTP response code for HTTP probe
 	CreatedProbeID    string // ID of the created probe
+
+	// Probe lifecycle
+	CreatedProbes []string // Names of the probes created during the run
+	DeleteProbes  bool     // Flag to determine if the probes created during the run are deleted at teardown
+
+	// Inline probes
+	InlineProbePaths []string // Paths of the probe definitions rendered inline in the ChaosEngine
+
+	// Probe definition, used by the probe types other than the HTTP GET probe
+	ProbeDefinitionPath     string // Path of a YAML probe definition, which takes precedence over the probe ENVs
+	ProbeHTTPMethod         string // Method of the HTTP probe (GET or POST)
+	ProbeHTTPBody           string // Body of the HTTP POST probe
+	ProbeHTTPContentType    string // Content type of the HTTP POST probe body
+	ProbeCommand            string // Command run by the CMD probe
+	ProbeSourceImage        string // Image of the pod the CMD probe command runs in, inline if empty
+	ProbeComparatorType     string // Type of the comparator (int, float or string) of the CMD and Prometheus probes
+	ProbeComparatorCriteria string // Criteria of the comparator, like ==, >= or contains
+	ProbeComparatorValue    string // Value the probe output is compared with
+	ProbeK8sGroup           string // API group of the resource checked by the K8s probe
+	ProbeK8sVersion         string // API version of the resource checked by the K8s probe
+	ProbeK8sResource        string // Resource checked by the K8s probe, like pods or deployments
+	ProbeK8sNamespace       string // Namespace of the resource checked by the K8s probe
+	ProbeK8sResourceNames   string // Comma separated names of the resources checked by the K8s probe
+	ProbeK8sFieldSelector   string // Field selector of the resources checked by the K8s probe
+	ProbeK8sLabelSelector   string // Label selector of the resources checked by the K8s probe
+	ProbeK8sOperation       string // Operation of the K8s probe (present, absent, create or delete)
+	ProbePromEndpoint       string // Endpoint of the Prometheus server queried by the Prometheus probe
+	ProbePromQuery          string // PromQL query of the Prometheus probe
+
+	// Pass criteria, checked on top of the final phase of the run
+	MinResiliencyScore        float64  // Minimum resiliency score of the run, not checked if 0
+	MinProbeSuccessPercentage float64  // Minimum probe success percentage of every fault, not checked if 0
+	MustPassProbes            []string // Names of the probes which need to pass
+
+	// Debugging
+	TailLogs     bool   // Stream the logs of the workflow and chaos pods of the run while it is in progress
+	ArtifactsDir string // Directory the artifacts of the failed runs are collected to, not collected if empty
 }
@@ -0,0 +1,78 @@
+package verdict
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+)
+
+// Criteria are the thresholds a run needs to meet on top of its final phase
+type Criteria struct {
+	MinResiliencyScore        float64  // Not checked if 0
+	MinProbeSuccessPercentage float64  // Checked for every fault, unmet without any fault results, not checked if 0
+	MustPassProbes            []string // Probes which need to run and pass in every fault they are attached to
+}
+
+// CriteriaFromExperimentDetails returns the criteria set by the ENVs or the scenario file
+func CriteriaFromExperimentDetails(experimentsDetails *types.ExperimentDetails) Criteria {
+	return Criteria{
+		MinResiliencyScore:        experimentsDetails.MinResiliencyScore,
+		MinProbeSuccessPercentage: experimentsDetails.MinProbeSuccessPercentage,
+		MustPassProbes:            experimentsDetails.MustPassProbes,
+	}
+}
+
+// IsZero checks if no criteria are set
+func (c Criteria) IsZero() bool {
+	return c.MinResiliencyScore == 0 && c.MinProbeSuccessPercentage == 0 && len(c.MustPassProbes) == 0
+}
+
+// Evaluate returns the criteria the verdict doesn't meet, empty if it meets them all
+func (c Criteria) Evaluate(v *Verdict) []string {
+	if c.IsZero() {
+		return nil
+	}
+	if v == nil {
+		return []string{"no execution data available to check the pass criteria"}
+	}
+
+	var unmet []string
+	if c.MinResiliencyScore != 0 {
+		switch {
+		case v.ResiliencyScore == nil:
+			unmet = append(unmet, fmt.Sprintf("resiliency score not available, expected at least %g", c.MinResiliencyScore))
+		case *v.ResiliencyScore < c.MinResiliencyScore:
+			unmet = append(unmet, fmt.Sprintf("resiliency score %g is below %g", *v.ResiliencyScore, c.MinResiliencyScore))
+		}
+	}
+
+	if c.MinProbeSuccessPercentage != 0 {
+		if len(v.Faults) == 0 {
+			unmet = append(unmet, fmt.Sprintf("no fault results to check probe success against, expected at least %g%%", c.MinProbeSuccessPercentage))
+		}
+		for _, fault := range v.Faults {
+			if fault.ProbeSuccessPercentage < c.MinProbeSuccessPercentage {
+				unmet = append(unmet, fmt.Sprintf("probe success of fault %s is %g%%, below %g%%", fault.Name, fault.ProbeSuccessPercentage, c.MinProbeSuccessPercentage))
+			}
+		}
+	}
+
+	for _, name := range c.MustPassProbes {
+		found := false
+		for _, fault := range v.Faults {
+			for _, probe := range fault.Probes {
+				if probe.Name != name {
+					continue
+				}
+				found = true
+				if probe.Verdict != "Passed" {
+					unmet = append(unmet, fmt.Sprintf("probe %s of fault %s must pass, but got %s: %s", name, fault.Name, probe.Verdict, probe.Description))
+				}
+			}
+		}
+		if !found {
+			unmet = append(unmet, fmt.Sprintf("probe %s must pass, but it didn't run", name))
+		}
+	}
+	return unmet
+}
@@ -0,0 +1,31 @@
+package verdict
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEvaluateProbeSuccessWithoutFaults(t *testing.T) {
+	criteria := Criteria{MinProbeSuccessPercentage: 100}
+
+	unmet := criteria.Evaluate(&Verdict{Phase: "Completed"})
+	if len(unmet) != 1 || !strings.Contains(unmet[0], "no fault results to check probe success against") {
+		t.Errorf("expected the missing fault results to be unmet, got %q", unmet)
+	}
+}
+
+func TestEvaluateProbeSuccess(t *testing.T) {
+	criteria := Criteria{MinProbeSuccessPercentage: 100}
+	v := &Verdict{
+		Phase: "Completed",
+		Faults: []Fault{
+			{Name: "pod-delete", ProbeSuccessPercentage: 100},
+			{Name: "pod-cpu-hog", ProbeSuccessPercentage: 50},
+		},
+	}
+
+	unmet := criteria.Evaluate(v)
+	if len(unmet) != 1 || !strings.Contains(unmet[0], "fault pod-cpu-hog is 50%") {
+		t.Errorf("expected only the probe success of pod-cpu-hog to be unmet, got %q", unmet)
+	}
+}
@@ -0,0 +1,307 @@
+package verdict
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	"github.com/pkg/errors"
+)
+
+// ExecutionData is the execution data of an experiment run, as stored by ChaosCenter
+type ExecutionData struct {
+	ExperimentType string          `json:"experimentType"`
+	ExperimentID   string          `json:"experimentID"`
+	RevisionID     string          `json:"revisionID"`
+	Name           string          `json:"name"`
+	Namespace      string          `json:"namespace"`
+	Phase          string          `json:"phase"`
+	Message        string          `json:"message"`
+	StartedAt      string          `json:"startedAt"`
+	FinishedAt     string          `json:"finishedAt"`
+	Nodes          map[string]Node `json:"nodes"`
+}
+
+// Node is a node of the Argo workflow of the run, the ChaosEngine nodes carry the chaos data of their fault
+type Node struct {
+	Name       string     `json:"name"`
+	Phase      string     `json:"phase"`
+	Message    string     `json:"message"`
+	StartedAt  string     `json:"startedAt"`
+	FinishedAt string     `json:"finishedAt"`
+	Children   []string   `json:"children"`
+	Type       string     `json:"type"`
+	ChaosData  *ChaosData `json:"chaosData,omitempty"`
+}
+
+// ChaosData is the state of a fault, as reported by the chaos exporter
+type ChaosData struct {
+	EngineUID              string       `json:"engineUID"`
+	EngineName             string       `json:"engineName"`
+	Namespace              string       `json:"namespace"`
+	ExperimentName         string       `json:"experimentName"`
+	ExperimentStatus       string       `json:"experimentStatus"`
+	ExperimentVerdict      string       `json:"experimentVerdict"`
+	ExperimentPod          string       `json:"experimentPod"`
+	RunnerPod              string       `json:"runnerPod"`
+	ProbeSuccessPercentage string       `json:"probeSuccessPercentage"`
+	FailStep               string       `json:"failStep"`
+	ChaosResult            *ChaosResult `json:"chaosResult"`
+}
+
+// ChaosResult holds the fields of the ChaosResult of a fault which make up its verdict. The
+// types of the chaos-operator API in use predate the probe statuses, hence the types of its own.
+type ChaosResult struct {
+	Status struct {
+		ExperimentStatus struct {
+			Phase                  string       `json:"phase"`
+			Verdict                string       `json:"verdict"`
+			FailStep               string       `json:"failStep"`
+			ProbeSuccessPercentage string       `json:"probeSuccessPercentage"`
+			ErrorOutput            *ErrorOutput `json:"errorOutput,omitempty"`
+		} `json:"experimentStatus"`
+		ProbeStatuses []ProbeStatus `json:"probeStatuses"`
+	} `json:"status"`
+}
+
+// ErrorOutput is the error which failed a fault
+type ErrorOutput struct {
+	ErrorCode string `json:"errorCode"`
+	Reason    string `json:"reason"`
+}
+
+// ProbeStatus is the status of a probe of a fault
+type ProbeStatus struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Mode   string `json:"mode"`
+	Status struct {
+		Verdict     string `json:"verdict"`
+		Description string `json:"description"`
+	} `json:"status"`
+}
+
+// Verdict is the outcome of an experiment run, down to its steps, faults and probes
+type Verdict struct {
+	ExperimentRunID string
+	Phase           string
+	Message         string
+	ResiliencyScore *float64 // Set by ChaosCenter once the run is done
+	FaultsPassed    int
+	FaultsFailed    int
+	FaultsAwaited   int
+	FaultsStopped   int
+	FaultsNA        int
+	TotalFaults     int
+	Steps           []Step  // Steps of the Argo workflow, in the order they started
+	Faults          []Fault // Faults of the run, in the order they started
+}
+
+// Step is the status of a node of the Argo workflow of the run
+type Step struct {
+	Name       string
+	Type       string
+	Phase      string
+	Message    string
+	StartedAt  string
+	FinishedAt string
+}
+
+// Fault is the verdict of a fault of the run
+type Fault struct {
+	Name                   string
+	EngineName             string
+	Namespace              string
+	Weightage              int
+	Status                 string // Status of the fault in the chaos exporter, like Completed
+	Verdict                string // Pass, Fail, Awaited, Stopped or N/A
+	FailStep               string
+	ProbeSuccessPercentage float64
+	Probes                 []Probe
+}
+
+// Probe is the verdict of a probe of a fault
+type Probe struct {
+	Name        string
+	Type        string
+	Mode        string
+	Verdict     string // Passed, Failed, Awaited or N/A
+	Description string
+}
+
+// Failed checks if the probe failed
+func (p *Probe) Failed() bool {
+	return p.Verdict == "Failed"
+}
+
+// Failed checks if the fault failed
+func (f *Fault) Failed() bool {
+	return f.Verdict == "Fail"
+}
+
+// FailedProbes returns the probes of the fault which failed
+func (f *Fault) FailedProbes() []Probe {
+	var failed []Probe
+	for _, probe := range f.Probes {
+		if probe.Failed() {
+			failed = append(failed, probe)
+		}
+	}
+	return failed
+}
+
+// Parse returns the verdict of the experiment run from its execution data
+func Parse(run *models.ExperimentRun) (*Verdict, error) {
+	verdict := &Verdict{
+		ExperimentRunID: run.ExperimentRunID,
+		Phase:           string(run.Phase),
+		ResiliencyScore: run.ResiliencyScore,
+		FaultsPassed:    valueOf(run.FaultsPassed),
+		FaultsFailed:    valueOf(run.FaultsFailed),
+		FaultsAwaited:   valueOf(run.FaultsAwaited),
+		FaultsStopped:   valueOf(run.FaultsStopped),
+		FaultsNA:        valueOf(run.FaultsNa),
+		TotalFaults:     valueOf(run.TotalFaults),
+	}
+	if run.ExecutionData == "" {
+		return verdict, nil
+	}
+
+	var data ExecutionData
+	if err := json.Unmarshal([]byte(run.ExecutionData), &data); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the execution data of experiment run %s", run.ExperimentRunID)
+	}
+	verdict.Message = data.Message
+
+	for _, node := range data.SortedNodes() {
+		verdict.Steps = append(verdict.Steps, Step{
+			Name:       node.Name,
+			Type:       node.Type,
+			Phase:      node.Phase,
+			Message:    node.Message,
+			StartedAt:  node.StartedAt,
+			FinishedAt: node.FinishedAt,
+		})
+		if node.Type == "ChaosEngine" && node.ChaosData != nil {
+			verdict.Faults = append(verdict.Faults, newFault(node, run.Weightages))
+		}
+	}
+	return verdict, nil
+}
+
+// SortedNodes returns the nodes of the Argo workflow in the order they started, the nodes
+// which haven't started yet come first
+func (d *ExecutionData) SortedNodes() []Node {
+	nodes := make([]Node, 0, len(d.Nodes))
+	for _, node := range d.Nodes {
+		nodes = append(nodes, node)
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].StartedAt != nodes[j].StartedAt {
+			return nodes[i].StartedAt < nodes[j].StartedAt
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	return nodes
+}
+
+// newFault returns the verdict of the fault of the ChaosEngine node
+func newFault(node Node, weightages []*models.Weightages) Fault {
+	chaosData := node.ChaosData
+	fault := Fault{
+		Name:       chaosData.ExperimentName,
+		EngineName: chaosData.EngineName,
+		Namespace:  chaosData.Namespace,
+		Status:     chaosData.ExperimentStatus,
+		Verdict:    chaosData.ExperimentVerdict,
+		FailStep:   chaosData.FailStep,
+	}
+	if fault.Name == "" {
+		fault.Name = node.Name
+	}
+	// The weightages are set per fault name, a substring would also match the faults named after it, like pod-delete in pod-delete-extended
+	for _, weightage := range weightages {
+		if weightage != nil && weightage.FaultName == fault.Name {
+			fault.Weightage = weightage.Weightage
+		}
+	}
+
+	probeSuccessPercentage := chaosData.ProbeSuccessPercentage
+	if result := chaosData.ChaosResult; result != nil {
+		status := result.Status.ExperimentStatus
+		if fault.Verdict == "" {
+			fault.Verdict = status.Verdict
+		}
+		if fault.FailStep == "" {
+			fault.FailStep = status.FailStep
+		}
+		if fault.FailStep == "" && status.ErrorOutput != nil {
+			fault.FailStep = status.ErrorOutput.Reason
+		}
+		if probeSuccessPercentage == "" {
+			probeSuccessPercentage = status.ProbeSuccessPercentage
+		}
+		for _, probe := range result.Status.ProbeStatuses {
+			fault.Probes = append(fault.Probes, Probe{
+				Name:        probe.Name,
+				Type:        probe.Type,
+				Mode:        probe.Mode,
+				Verdict:     probe.Status.Verdict,
+				Description: probe.Status.Description,
+			})
+		}
+	}
+	// The percentage is Awaited until the fault completes
+	if value, err := strconv.ParseFloat(probeSuccessPercentage, 64); err == nil {
+		fault.ProbeSuccessPercentage = value
+	}
+	return fault
+}
+
+// Failures describes why the run failed, down to the probes which failed. It is empty for a run without failures.
+func (v *Verdict) Failures() []string {
+	var failures []string
+	for _, fault := range v.Faults {
+		if !fault.Failed() && len(fault.FailedProbes()) == 0 {
+			continue
+		}
+		failure := fmt.Sprintf("fault %s: verdict %s", fault.Name, fault.Verdict)
+		if fault.FailStep != "" {
+			failure += fmt.Sprintf(", failed at %q", fault.FailStep)
+		}
+		failure += fmt.Sprintf(", probe success %g%%", fault.ProbeSuccessPercentage)
+		failures = append(failures, failure)
+		for _, probe := range fault.FailedProbes() {
+			failures = append(failures, fmt.Sprintf("  probe %s (%s, %s) failed: %s", probe.Name, probe.Type, probe.Mode, probe.Description))
+		}
+	}
+	for _, step := range v.Steps {
+		if (step.Phase == "Failed" || step.Phase == "Error") && step.Type != "ChaosEngine" && step.Message != "" {
+			failures = append(failures, fmt.Sprintf("step %s: %s, %s", step.Name, step.Phase, step.Message))
+		}
+	}
+	return failures
+}
+
+// String returns a summary of the verdict, along with its failures
+func (v *Verdict) String() string {
+	summary := fmt.Sprintf("phase %s, %d/%d faults passed", v.Phase, v.FaultsPassed, v.TotalFaults)
+	if v.ResiliencyScore != nil {
+		summary += fmt.Sprintf(", resiliency score %g", *v.ResiliencyScore)
+	}
+	if failures := v.Failures(); len(failures) != 0 {
+		summary += "\n" + strings.Join(failures, "\n")
+	}
+	return summary
+}
+
+// valueOf returns the value of the int pointer, 0 if nil
+func valueOf(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
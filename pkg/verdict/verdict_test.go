@@ -0,0 +1,145 @@
+package verdict
+
+import (
+	"reflect"
+	"testing"
+
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+func TestParse(t *testing.T) {
+	score := 50.0
+	tests := []struct {
+		name string
+		run  *models.ExperimentRun
+		want *Verdict
+	}{
+		{
+			name: "argo nodes",
+			run: &models.ExperimentRun{
+				ExperimentRunID: "run",
+				Phase:           "Completed",
+				ResiliencyScore: &score,
+				Weightages: []*models.Weightages{
+					{FaultName: "pod-delete-extended", Weightage: 5},
+					{FaultName: "pod-delete", Weightage: 10},
+				},
+				ExecutionData: `{"message":"done","nodes":{
+					"wf":{"name":"pod-delete-abc12","type":"Steps","phase":"Succeeded","startedAt":"2024-01-01T00:00:00Z"},
+					"install":{"name":"install-chaos-faults","type":"Pod","phase":"Succeeded","startedAt":"2024-01-01T00:00:01Z"},
+					"engine":{"name":"pod-delete-ce5","type":"ChaosEngine","phase":"Succeeded","startedAt":"2024-01-01T00:00:02Z",
+						"chaosData":{"engineName":"pod-delete-ce5x7k","namespace":"litmus","experimentName":"pod-delete","experimentStatus":"Completed","experimentVerdict":"Pass","probeSuccessPercentage":"100"}},
+					"extended":{"name":"pod-delete-extended-ce5","type":"ChaosEngine","phase":"Succeeded","startedAt":"2024-01-01T00:00:03Z",
+						"chaosData":{"engineName":"pod-delete-extended-ce5b2m","namespace":"litmus","experimentName":"pod-delete-extended","experimentStatus":"Completed","experimentVerdict":"Pass","probeSuccessPercentage":"100"}}
+				}}`,
+			},
+			want: &Verdict{
+				ExperimentRunID: "run",
+				Phase:           "Completed",
+				Message:         "done",
+				ResiliencyScore: &score,
+				Steps: []Step{
+					{Name: "pod-delete-abc12", Type: "Steps", Phase: "Succeeded", StartedAt: "2024-01-01T00:00:00Z"},
+					{Name: "install-chaos-faults", Type: "Pod", Phase: "Succeeded", StartedAt: "2024-01-01T00:00:01Z"},
+					{Name: "pod-delete-ce5", Type: "ChaosEngine", Phase: "Succeeded", StartedAt: "2024-01-01T00:00:02Z"},
+					{Name: "pod-delete-extended-ce5", Type: "ChaosEngine", Phase: "Succeeded", StartedAt: "2024-01-01T00:00:03Z"},
+				},
+				Faults: []Fault{
+					{Name: "pod-delete", EngineName: "pod-delete-ce5x7k", Namespace: "litmus", Weightage: 10, Status: "Completed", Verdict: "Pass", ProbeSuccessPercentage: 100},
+					{Name: "pod-delete-extended", EngineName: "pod-delete-extended-ce5b2m", Namespace: "litmus", Weightage: 5, Status: "Completed", Verdict: "Pass", ProbeSuccessPercentage: 100},
+				},
+			},
+		},
+		{
+			name: "chaos result fallback",
+			run: &models.ExperimentRun{
+				ExperimentRunID: "run",
+				Phase:           "Completed",
+				ExecutionData: `{"nodes":{
+					"engine":{"name":"pod-cpu-hog-ce5","type":"ChaosEngine","phase":"Failed","startedAt":"2024-01-01T00:00:02Z",
+						"chaosData":{"engineName":"pod-cpu-hog-ce5x7k","namespace":"litmus","experimentName":"pod-cpu-hog","experimentStatus":"Completed",
+							"chaosResult":{"status":{
+								"experimentStatus":{"verdict":"Fail","probeSuccessPercentage":"50","errorOutput":{"errorCode":"STATUS_CHECKS_ERROR","reason":"application pod is not running"}},
+								"probeStatuses":[
+									{"name":"check-app","type":"httpProbe","mode":"Continuous","status":{"verdict":"Failed","description":"status code 500"}},
+									{"name":"check-db","type":"cmdProbe","mode":"SOT","status":{"verdict":"Passed","description":"probe passed"}}
+								]}}}}
+				}}`,
+			},
+			want: &Verdict{
+				ExperimentRunID: "run",
+				Phase:           "Completed",
+				Steps: []Step{
+					{Name: "pod-cpu-hog-ce5", Type: "ChaosEngine", Phase: "Failed", StartedAt: "2024-01-01T00:00:02Z"},
+				},
+				Faults: []Fault{
+					{
+						Name:                   "pod-cpu-hog",
+						EngineName:             "pod-cpu-hog-ce5x7k",
+						Namespace:              "litmus",
+						Status:                 "Completed",
+						Verdict:                "Fail",
+						FailStep:               "application pod is not running",
+						ProbeSuccessPercentage: 50,
+						Probes: []Probe{
+							{Name: "check-app", Type: "httpProbe", Mode: "Continuous", Verdict: "Failed", Description: "status code 500"},
+							{Name: "check-db", Type: "cmdProbe", Mode: "SOT", Verdict: "Passed", Description: "probe passed"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "awaited probe success and no resiliency score",
+			run: &models.ExperimentRun{
+				ExperimentRunID: "run",
+				Phase:           "Running",
+				ExecutionData: `{"nodes":{
+					"engine":{"name":"pod-delete-ce5","type":"ChaosEngine","phase":"Running","startedAt":"2024-01-01T00:00:02Z",
+						"chaosData":{"engineName":"pod-delete-ce5x7k","namespace":"litmus","experimentName":"pod-delete","experimentStatus":"Running","experimentVerdict":"Awaited","probeSuccessPercentage":"Awaited"}}
+				}}`,
+			},
+			want: &Verdict{
+				ExperimentRunID: "run",
+				Phase:           "Running",
+				Steps: []Step{
+					{Name: "pod-delete-ce5", Type: "ChaosEngine", Phase: "Running", StartedAt: "2024-01-01T00:00:02Z"},
+				},
+				Faults: []Fault{
+					{Name: "pod-delete", EngineName: "pod-delete-ce5x7k", Namespace: "litmus", Status: "Running", Verdict: "Awaited"},
+				},
+			},
+		},
+		{
+			name: "no fault nodes",
+			run: &models.ExperimentRun{
+				ExperimentRunID: "run",
+				Phase:           "Error",
+				ExecutionData: `{"message":"install failed","nodes":{
+					"install":{"name":"install-chaos-faults","type":"Pod","phase":"Error","message":"image pull failed","startedAt":"2024-01-01T00:00:01Z"},
+					"engine":{"name":"pod-delete-ce5","type":"ChaosEngine","phase":"Pending"}
+				}}`,
+			},
+			want: &Verdict{
+				ExperimentRunID: "run",
+				Phase:           "Error",
+				Message:         "install failed",
+				Steps: []Step{
+					{Name: "pod-delete-ce5", Type: "ChaosEngine", Phase: "Pending"},
+					{Name: "install-chaos-faults", Type: "Pod", Phase: "Error", Message: "image pull failed", StartedAt: "2024-01-01T00:00:01Z"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.run)
+			if err != nil {
+				t.Fatalf("Parse returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected verdict\n%+v\ngot\n%+v", tt.want, got)
+			}
+		})
+	}
+}
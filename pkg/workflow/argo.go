@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Workflow is the subset of the Argo Workflow used to run chaos experiments
+type Workflow struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Metadata   WorkflowMetadata       `json:"metadata"`
+	Spec       WorkflowSpec           `json:"spec"`
+	Status     map[string]interface{} `json:"status"`
+}
+
+// WorkflowMetadata holds the metadata of a Workflow
+type WorkflowMetadata struct {
+	Name      string            `json:"name,omitempty"`
+	Namespace string            `json:"namespace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+}
+
+// WorkflowSpec holds the spec of a Workflow
+type WorkflowSpec struct {
+	Entrypoint         string                        `json:"entrypoint"`
+	ServiceAccountName string                        `json:"serviceAccountName,omitempty"`
+	PodGC              *PodGC                        `json:"podGC,omitempty"`
+	SecurityContext    *corev1.PodSecurityContext    `json:"securityContext,omitempty"`
+	Arguments          Arguments                     `json:"arguments"`
+	Templates          []Template                    `json:"templates"`
+	ImagePullSecrets   []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// PodGC describes how the workflow pods are garbage collected
+type PodGC struct {
+	Strategy string `json:"strategy"`
+}
+
+// Arguments holds the parameters passed to a workflow or a step
+type Arguments struct {
+	Parameters []Parameter `json:"parameters,omitempty"`
+}
+
+// Parameter is a named workflow parameter
+type Parameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Template is a single workflow template, either a list of steps or a container
+type Template struct {
+	Name      string            `json:"name"`
+	Steps     [][]WorkflowStep  `json:"steps,omitempty"`
+	Inputs    *Inputs           `json:"inputs,omitempty"`
+	Outputs   *Outputs          `json:"outputs,omitempty"`
+	Metadata  *TemplateMetadata `json:"metadata,omitempty"`
+	Container *corev1.Container `json:"container,omitempty"`
+}
+
+// WorkflowStep references the template to run within a step
+type WorkflowStep struct {
+	Name      string    `json:"name"`
+	Template  string    `json:"template"`
+	Arguments Arguments `json:"arguments"`
+}
+
+// Inputs holds the input artifacts of a template
+type Inputs struct {
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
+
+// Outputs holds the outputs of a template
+type Outputs struct{}
+
+// Artifact is a file made available to the template container
+type Artifact struct {
+	Name string       `json:"name"`
+	Path string       `json:"path"`
+	Raw  *RawArtifact `json:"raw,omitempty"`
+}
+
+// RawArtifact holds the inline content of an artifact
+type RawArtifact struct {
+	Data string `json:"data"`
+}
+
+// TemplateMetadata holds the metadata added to the template pods
+type TemplateMetadata struct {
+	Labels map[string]string `json:"labels,omitempty"`
+}
@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+)
+
+// Fault is a single chaos fault of a composed experiment along with its own configuration
+type Fault struct {
+	Type   ExperimentType
+	Config ExperimentConfig
+}
+
+// FaultStep is a group of faults which are injected in parallel.
+// The steps of a composed experiment are executed one after the other.
+type FaultStep []Fault
+
+// GetComposedExperimentManifest returns the workflow manifest which runs the given steps of faults
+// sequentially, injecting the faults of every step in parallel
+func GetComposedExperimentManifest(experimentName string, steps []FaultStep) (string, error) {
+	return buildWorkflowManifest(experimentName, "composed-chaos-engine", steps)
+}
+
+// ConstructComposedExperimentRequest creates a single experiment request for all the given steps of faults
+func ConstructComposedExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string, steps []FaultStep) (*models.SaveChaosExperimentRequest, error) {
+	// The inline probes of the environment are attached to every fault which has none of its own
+	inlineProbes, err := loadInlineProbes(details)
+	if err != nil {
+		return nil, err
+	}
+	if len(inlineProbes) != 0 {
+		steps = withInlineProbesInSteps(steps, inlineProbes)
+	}
+	if steps, err = withTargetNodeInSteps(steps); err != nil {
+		return nil, err
+	}
+
+	manifest, err := GetComposedExperimentManifest(experimentName, steps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get composed experiment manifest: %v", err)
+	}
+	if err := ValidateManifest(manifest); err != nil {
+		return nil, err
+	}
+
+	var stepNames []string
+	tags := []string{}
+	for _, step := range steps {
+		var faultNames []string
+		for _, fault := range step {
+			faultNames = append(faultNames, string(fault.Type))
+			for _, tag := range fault.Config.Tags {
+				if !pkg.ContainsString(tags, tag) {
+					tags = append(tags, tag)
+				}
+			}
+		}
+		stepNames = append(stepNames, strings.Join(faultNames, " + "))
+	}
+
+	experimentRequest := &models.SaveChaosExperimentRequest{
+		ID:          experimentID,
+		Name:        experimentName,
+		InfraID:     details.ConnectedInfraID,
+		Description: "Composed chaos experiment execution: " + strings.Join(stepNames, " -> "),
+		Tags:        tags,
+		Manifest:    manifest,
+	}
+
+	return experimentRequest, nil
+}
+
+// withInlineProbesInSteps returns a copy of the steps where the faults without inline probes get the given ones
+func withInlineProbesInSteps(steps []FaultStep, inlineProbes []probe.Definition) []FaultStep {
+	var result []FaultStep
+	for _, step := range steps {
+		var faults FaultStep
+		for _, fault := range step {
+			fault.Config = withInlineProbes(fault.Config, inlineProbes)
+			faults = append(faults, fault)
+		}
+		result = append(result, faults)
+	}
+	return result
+}
+
+// ParseFaultSteps builds the fault steps from a spec like "pod-delete;pod-cpu-hog,pod-memory-hog"
+// where the steps are separated by ';' and the parallel faults of a step by ','.
+// Every fault gets its default configuration along with the probe configuration from the environment,
+// and the node lifecycle faults are pointed at the node of the application unless TARGET_NODE or NODE_LABEL is set.
+func ParseFaultSteps(spec string) ([]FaultStep, error) {
+	hub := getChaosHub()
+	var steps []FaultStep
+	var err error
+	for _, rawStep := range strings.Split(spec, ";") {
+		var step FaultStep
+		for _, rawFault := range strings.Split(rawStep, ",") {
+			name := strings.TrimSpace(rawFault)
+			if name == "" {
+				continue
+			}
+			experimentType := ExperimentType(name)
+			if _, err = hub.Get(name); err != nil {
+				return nil, fmt.Errorf("unsupported experiment type %s: %v", name, err)
+			}
+			config := GetDefaultExperimentConfig(experimentType)
+			ApplyProbeConfigFromEnv(&config)
+			if config, err = WithTargetNode(experimentType, config); err != nil {
+				return nil, err
+			}
+			step = append(step, Fault{Type: experimentType, Config: config})
+		}
+		if len(step) != 0 {
+			steps = append(steps, step)
+		}
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no faults found in %q", spec)
+	}
+	return steps, nil
+}
@@ -0,0 +1,123 @@
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFaultSteps(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+	t.Setenv("LITMUS_PROBES", "")
+
+	steps, err := ParseFaultSteps("pod-delete; pod-cpu-hog,pod-memory-hog")
+	if err != nil {
+		t.Fatalf("failed to parse the fault steps: %v", err)
+	}
+	var got [][]ExperimentType
+	for _, step := range steps {
+		var faults []ExperimentType
+		for _, fault := range step {
+			faults = append(faults, fault.Type)
+		}
+		got = append(got, faults)
+	}
+	want := [][]ExperimentType{{PodDelete}, {PodCPUHog, PodMemoryHog}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the steps %v, got %v", want, got)
+	}
+	if steps[1][0].Config.CPUCores != "1" {
+		t.Errorf("expected the faults to get their default config, got %+v", steps[1][0].Config)
+	}
+}
+
+func TestParseFaultStepsErrors(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "", wantErr: "no faults found"},
+		{spec: " ; , ", wantErr: "no faults found"},
+		{spec: "pod-delete;pod-delete-typo", wantErr: "unsupported experiment type pod-delete-typo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseFaultSteps(tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGetComposedExperimentManifest(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+
+	deleteConfig := GetDefaultExperimentConfig(PodDelete)
+	deleteConfig.Probes = []ProbeRef{{Name: "http-health", Mode: "Continuous"}}
+	cpuConfig := GetDefaultExperimentConfig(PodCPUHog)
+	cpuConfig.ProbeName = "cpu-check"
+	cpuConfig.ProbeMode = "EOT"
+	secondDeleteConfig := GetDefaultExperimentConfig(PodDelete)
+	secondDeleteConfig.ProbeName = ""
+
+	steps := []FaultStep{
+		{{Type: PodDelete, Config: deleteConfig}},
+		{{Type: PodCPUHog, Config: cpuConfig}, {Type: PodDelete, Config: secondDeleteConfig}},
+	}
+	manifest, err := GetComposedExperimentManifest("composed-test", steps)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		t.Fatalf("failed to parse the workflow: %v", err)
+	}
+	var layout [][]string
+	for _, step := range workflow.Spec.Templates[0].Steps {
+		var names []string
+		for _, s := range step {
+			names = append(names, s.Template)
+		}
+		layout = append(layout, names)
+	}
+	wantLayout := [][]string{
+		{"install-chaos-faults"},
+		{"pod-delete-ce5"},
+		{"pod-cpu-hog-ce5", "pod-delete-ce5-2"},
+		{"cleanup-chaos-resources"},
+	}
+	if !reflect.DeepEqual(layout, wantLayout) {
+		t.Errorf("expected the steps %v, got %v", wantLayout, layout)
+	}
+
+	// The ChaosExperiment of a repeated fault is only installed once
+	if artifacts := workflow.Spec.Templates[1].Inputs.Artifacts; len(artifacts) != 2 {
+		t.Errorf("expected the ChaosExperiments of the 2 distinct faults to be installed, got %d", len(artifacts))
+	}
+
+	probeRefs := map[string]string{
+		"pod-delete-ce5":   `[{"name":"http-health","mode":"Continuous"}]`,
+		"pod-cpu-hog-ce5":  `[{"name":"cpu-check","mode":"EOT"}]`,
+		"pod-delete-ce5-2": "",
+	}
+	for templateName, want := range probeRefs {
+		if got := probeRefAnnotation(renderEngine(t, manifest, templateName)); got != want {
+			t.Errorf("expected the probeRef annotation %s on %s, got %s", want, templateName, got)
+		}
+	}
+}
+
+func TestGetComposedExperimentManifestRejectsEmptyStep(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+
+	steps := []FaultStep{{{Type: PodDelete, Config: GetDefaultExperimentConfig(PodDelete)}}, {}}
+	_, err := GetComposedExperimentManifest("composed-test", steps)
+	if err == nil || !strings.Contains(err.Error(), "empty fault step") {
+		t.Errorf("expected the empty step to be rejected, got: %v", err)
+	}
+}
@@ -0,0 +1,570 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoshub"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"github.com/litmuschaos/chaos-operator/pkg/apis/litmuschaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// defaultLibImage is the helper image used by the faults which need one
+	defaultLibImage = "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
+	// defaultSocketPath is the containerd socket path on the nodes
+	defaultSocketPath = "/run/containerd/containerd.sock"
+)
+
+// isNodeExperiment returns true if the experiment type targets nodes instead of an application
+func isNodeExperiment(experimentType ExperimentType) bool {
+	return experimentType == NodeCPUHog ||
+		experimentType == NodeMemoryHog ||
+		experimentType == NodeIOStress ||
+		experimentType == NodeDrain ||
+		experimentType == NodeTaint ||
+		experimentType == KubeletServiceKill ||
+		experimentType == NodeRestart
+}
+
+// TargetsNode returns true for the node lifecycle faults, which act on a single node and don't take an appinfo.
+// Without a TARGET_NODE or NODE_LABEL, litmus picks any node of the cluster for them.
+func TargetsNode(experimentType ExperimentType) bool {
+	return experimentType == NodeDrain ||
+		experimentType == NodeTaint ||
+		experimentType == KubeletServiceKill ||
+		experimentType == NodeRestart
+}
+
+// ProbeRef references an existing ChaosCenter probe from the ChaosEngine annotations
+type ProbeRef struct {
+	Name string `json:"name"`
+	Mode string `json:"mode"`
+}
+
+// getProbeRefs returns the probes attached to the fault. The single probe set by ProbeName
+// is only attached when no Probes are set, as it defaults to the legacy myprobe.
+func getProbeRefs(config ExperimentConfig) []ProbeRef {
+	if len(config.Probes) == 0 {
+		if config.ProbeName == "" {
+			return nil
+		}
+		return []ProbeRef{{Name: config.ProbeName, Mode: valueOr(config.ProbeMode, "SOT")}}
+	}
+
+	var probeRefs []ProbeRef
+	for _, ref := range config.Probes {
+		probeRefs = append(probeRefs, ProbeRef{Name: ref.Name, Mode: valueOr(ref.Mode, "SOT")})
+	}
+	return probeRefs
+}
+
+// envList is an ordered list of env variables, where the variables without a value are left out
+type envList []corev1.EnvVar
+
+// add appends the variable to the list only if it has a value
+func (e *envList) add(name, value string) {
+	if value == "" {
+		return
+	}
+	*e = append(*e, corev1.EnvVar{Name: name, Value: value})
+}
+
+// valueOr returns the value or the fallback if the value is empty
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// getFaultEnv returns the ChaosEngine env for the given fault derived from its configuration.
+// It returns false for the faults which have no typed configuration.
+func getFaultEnv(experimentType ExperimentType, config ExperimentConfig) ([]corev1.EnvVar, bool) {
+	var env envList
+
+	switch experimentType {
+	case PodDelete:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("FORCE", "true")
+		env.add("CHAOS_INTERVAL", config.ChaosInterval)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+
+	case PodCPUHog:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("CPU_CORES", config.CPUCores)
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("RAMP_TIME", config.RampTime)
+
+	case PodMemoryHog:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("MEMORY_CONSUMPTION", config.MemoryConsumption)
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("RAMP_TIME", config.RampTime)
+
+	case PodIOStress:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("FILESYSTEM_UTILIZATION_PERCENTAGE", config.FilesystemUtilizationPercentage)
+		env.add("FILESYSTEM_UTILIZATION_BYTES", config.FilesystemUtilizationBytes)
+		env.add("NUMBER_OF_WORKERS", config.NumberOfWorkers)
+		env.add("VOLUME_MOUNT_PATH", config.VolumeMountPath)
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("LIB_IMAGE", config.LibImage)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("CONTAINER_RUNTIME", config.ContainerRuntime)
+		env.add("SOCKET_PATH", config.SocketPath)
+		env.add("SEQUENCE", config.Sequence)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case PodNetworkCorruption, PodNetworkLatency, PodNetworkLoss, PodNetworkDuplication, PodNetworkRateLimit:
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("NETWORK_INTERFACE", config.NetworkInterface)
+		env.add("LIB_IMAGE", config.LibImage)
+		env.add("TC_IMAGE", config.TCImage)
+		switch experimentType {
+		case PodNetworkCorruption:
+			env.add("NETWORK_PACKET_CORRUPTION_PERCENTAGE", config.NetworkPacketCorruptionPercentage)
+		case PodNetworkLatency:
+			env.add("NETWORK_LATENCY", config.NetworkLatency)
+			env.add("JITTER", config.Jitter)
+		case PodNetworkLoss:
+			env.add("NETWORK_PACKET_LOSS_PERCENTAGE", config.NetworkPacketLossPercentage)
+		case PodNetworkDuplication:
+			env.add("NETWORK_PACKET_DUPLICATION_PERCENTAGE", config.NetworkPacketDuplicationPercentage)
+		case PodNetworkRateLimit:
+			env.add("NETWORK_BANDWIDTH", config.NetworkBandwidth)
+			env.add("BURST", config.Burst)
+			env.add("LIMIT", config.Limit)
+			env.add("MIN_BURST", config.MinBurst)
+			env.add("PEAK_RATE", config.PeakRate)
+			env.add("SOURCE_PORTS", config.SourcePorts)
+			env.add("DESTINATION_PORTS", config.DestinationPorts)
+		}
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("CONTAINER_RUNTIME", config.ContainerRuntime)
+		env.add("SOCKET_PATH", config.SocketPath)
+		env.add("DESTINATION_IPS", config.DestinationIPs)
+		env.add("DESTINATION_HOSTS", config.DestinationHosts)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("SEQUENCE", config.Sequence)
+
+	case PodHTTPLatency, PodHTTPStatusCode, PodHTTPModifyHeader, PodHTTPModifyBody, PodHTTPResetPeer:
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("LIB_IMAGE", config.LibImage)
+		switch experimentType {
+		case PodHTTPLatency:
+			env.add("LATENCY", config.Latency)
+		case PodHTTPStatusCode:
+			env.add("STATUS_CODE", config.StatusCode)
+			env.add("MODIFY_RESPONSE_BODY", config.ModifyResponseBody)
+			env.add("RESPONSE_BODY", config.ResponseBody)
+			env.add("CONTENT_ENCODING", config.ContentEncoding)
+			env.add("CONTENT_TYPE", config.ContentType)
+		case PodHTTPModifyHeader:
+			env.add("HEADERS_MAP", config.HeadersMap)
+			env.add("HEADER_MODE", config.HeaderMode)
+		case PodHTTPModifyBody:
+			env.add("RESPONSE_BODY", config.ResponseBody)
+			env.add("CONTENT_ENCODING", config.ContentEncoding)
+			env.add("CONTENT_TYPE", config.ContentType)
+		case PodHTTPResetPeer:
+			env.add("RESET_TIMEOUT", config.ResetTimeout)
+		}
+		env.add("TARGET_SERVICE_PORT", config.TargetServicePort)
+		env.add("PROXY_PORT", config.ProxyPort)
+		env.add("TOXICITY", config.Toxicity)
+		env.add("NETWORK_INTERFACE", config.NetworkInterface)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("CONTAINER_RUNTIME", config.ContainerRuntime)
+		env.add("SOCKET_PATH", config.SocketPath)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("SEQUENCE", config.Sequence)
+
+	case PodDNSError, PodDNSSpoof:
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		if experimentType == PodDNSError {
+			env.add("TARGET_HOSTNAMES", config.TargetHostnames)
+			env.add("MATCH_SCHEME", config.MatchScheme)
+		} else {
+			env.add("SPOOF_MAP", config.SpoofMap)
+		}
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("LIB_IMAGE", config.LibImage)
+		env.add("CONTAINER_RUNTIME", config.ContainerRuntime)
+		env.add("SOCKET_PATH", config.SocketPath)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("SEQUENCE", config.Sequence)
+
+	case PodNetworkPartition:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("POLICY_TYPES", config.PolicyTypes)
+		env.add("NAMESPACE_SELECTOR", config.NamespaceSelector)
+		env.add("POD_SELECTOR", config.PodSelector)
+		env.add("PORTS", config.Ports)
+		env.add("DESTINATION_IPS", config.DestinationIPs)
+		env.add("DESTINATION_HOSTS", config.DestinationHosts)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case PodAutoscaler:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("REPLICA_COUNT", config.ReplicaCount)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case ContainerKill:
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("CHAOS_INTERVAL", config.ChaosInterval)
+		env.add("SIGNAL", config.Signal)
+		env.add("SOCKET_PATH", config.SocketPath)
+		env.add("CONTAINER_RUNTIME", config.ContainerRuntime)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("LIB_IMAGE", valueOr(config.LibImage, hubLibImage(experimentType, defaultLibImage)))
+		env.add("SEQUENCE", config.Sequence)
+
+	case DiskFill:
+		env.add("TARGET_CONTAINER", config.TargetContainer)
+		env.add("FILL_PERCENTAGE", config.FillPercentage)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("DATA_BLOCK_SIZE", config.DataBlockSize)
+		env.add("TARGET_PODS", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("EPHEMERAL_STORAGE_MEBIBYTES", config.EphemeralStorageMebibytes)
+		env.add("PODS_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("LIB_IMAGE", valueOr(config.LibImage, hubLibImage(experimentType, defaultLibImage)))
+		env.add("SOCKET_PATH", valueOr(config.SocketPath, defaultSocketPath))
+		env.add("CONTAINER_RUNTIME", valueOr(config.ContainerRuntime, "containerd"))
+		env.add("SEQUENCE", config.Sequence)
+
+	case NodeCPUHog:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("CPU_LOAD", "100")
+		env.add("NODES_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_NODES", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("SEQUENCE", config.Sequence)
+
+	case NodeMemoryHog:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("MEMORY_CONSUMPTION_PERCENTAGE", config.MemoryConsumptionPercentage)
+		env.add("MEMORY_CONSUMPTION_MEBIBYTES", config.MemoryConsumptionMebibytes)
+		env.add("NUMBER_OF_WORKERS", config.NumberOfWorkers)
+		env.add("TARGET_NODES", config.TargetPods)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("NODES_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("LIB_IMAGE", valueOr(config.LibImage, hubLibImage(experimentType, defaultLibImage)))
+		env.add("SEQUENCE", config.Sequence)
+
+	case NodeIOStress:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("FILESYSTEM_UTILIZATION_PERCENTAGE", "10")
+		env.add("NODES_AFFECTED_PERC", config.PodsAffectedPerc)
+		env.add("TARGET_NODES", config.TargetPods)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+		env.add("SEQUENCE", config.Sequence)
+
+	case NodeDrain:
+		env.add("TARGET_NODE", config.TargetNode)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case NodeTaint:
+		env.add("TARGET_NODE", config.TargetNode)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("TAINTS", config.Taints)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case KubeletServiceKill:
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("LIB_IMAGE", config.LibImage)
+		env.add("TARGET_NODE", config.TargetNode)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	case NodeRestart:
+		env.add("SSH_USER", config.SSHUser)
+		env.add("TOTAL_CHAOS_DURATION", config.ChaosDuration)
+		env.add("REBOOT_COMMAND", config.RebootCommand)
+		env.add("TARGET_NODE", config.TargetNode)
+		env.add("NODE_LABEL", config.NodeLabel)
+		env.add("TARGET_NODE_IP", config.TargetNodeIP)
+		env.add("RAMP_TIME", config.RampTime)
+		env.add("LIB_IMAGE", config.LibImage)
+		env.add("DEFAULT_HEALTH_CHECK", config.DefaultHealthCheck)
+
+	default:
+		return nil, false
+	}
+
+	return env, true
+}
+
+// resolveFaultEnv returns the env of the fault, either from its typed configuration or from the
+// sample engine in the chaoshub, with the overrides of config.Env applied on top
+func resolveFaultEnv(fault Fault, hubFault *chaoshub.Fault) ([]corev1.EnvVar, error) {
+	env, typed := getFaultEnv(fault.Type, fault.Config)
+	if !typed {
+		hubEnv, err := hubFault.EngineEnv()
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, hubEnv...)
+	}
+
+	// Sorted to keep the generated manifest stable
+	var names []string
+	for name := range fault.Config.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := fault.Config.Env[name]
+		overridden := false
+		for i := range env {
+			if env[i].Name == name {
+				env[i].Value = value
+				overridden = true
+			}
+		}
+		if !overridden {
+			env = append(env, corev1.EnvVar{Name: name, Value: value})
+		}
+	}
+	return env, nil
+}
+
+// targetsApplication returns true if the fault needs the appinfo of the target application
+func targetsApplication(fault Fault, hubFault *chaoshub.Fault) bool {
+	if _, typed := getFaultEnv(fault.Type, fault.Config); typed {
+		return !isNodeExperiment(fault.Type)
+	}
+	return hubFault.TargetsApplication()
+}
+
+// getChaosEngine returns the ChaosEngine which injects the fault as a part of the given experiment
+func getChaosEngine(fault Fault, hubFault *chaoshub.Fault, experimentName string, images ImageConfig) (*v1alpha1.ChaosEngine, error) {
+	env, err := resolveFaultEnv(fault, hubFault)
+	if err != nil {
+		return nil, err
+	}
+	env = images.rewriteEnv(env)
+
+	engine := &v1alpha1.ChaosEngine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "litmuschaos.io/v1alpha1",
+			Kind:       "ChaosEngine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: string(fault.Type) + "-ce5",
+			Namespace:    "{{workflow.parameters.adminModeNamespace}}",
+			Labels: map[string]string{
+				"workflow_run_id": "{{ workflow.uid }}",
+				"workflow_name":   experimentName,
+			},
+		},
+		Spec: v1alpha1.ChaosEngineSpec{
+			EngineState:         v1alpha1.EngineStateActive,
+			ChaosServiceAccount: "litmus-admin",
+			Experiments: []v1alpha1.ExperimentList{
+				{
+					Name: string(fault.Type),
+					Spec: v1alpha1.ExperimentAttributes{
+						Components: v1alpha1.ExperimentComponents{
+							ENV:                        env,
+							ExperimentImagePullSecrets: images.pullSecrets(),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The runner image is only pinned when it has to be pulled from elsewhere
+	if runnerImage := images.Rewrite(defaultRunnerImage); runnerImage != defaultRunnerImage {
+		engine.Spec.Components.Runner.Image = runnerImage
+	}
+	engine.Spec.Components.Runner.ImagePullSecrets = images.pullSecrets()
+
+	// Node faults don't target an application
+	if !targetsApplication(fault, hubFault) {
+		engine.Spec.AnnotationCheck = "false"
+	} else {
+		engine.Spec.Appinfo = v1alpha1.ApplicationParams{
+			Appns:    fault.Config.AppNamespace,
+			Applabel: fault.Config.AppLabel,
+			AppKind:  fault.Config.AppKind,
+		}
+	}
+
+	if probeRefs := getProbeRefs(fault.Config); len(probeRefs) != 0 {
+		probeRef, _ := json.Marshal(probeRefs)
+		engine.Annotations = map[string]string{"probeRef": string(probeRef)}
+	}
+
+	return engine, nil
+}
+
+// getChaosExperiment returns the ChaosExperiment of the fault with the env defaults taken from its configuration
+func getChaosExperiment(fault Fault, hubFault *chaoshub.Fault, images ImageConfig) (string, error) {
+	experiment := map[string]interface{}{}
+	if err := yamlChe.Unmarshal(hubFault.Experiment, &experiment); err != nil {
+		return "", fmt.Errorf("failed to parse the chaos experiment of %s: %v", fault.Type, err)
+	}
+
+	// Override the default value of the env variables which are set for the fault
+	env, err := resolveFaultEnv(fault, hubFault)
+	if err != nil {
+		return "", err
+	}
+	faultEnv := map[string]string{}
+	for _, env := range env {
+		faultEnv[env.Name] = env.Value
+	}
+	envs, _, _ := unstructured.NestedSlice(experiment, "spec", "definition", "env")
+	for _, item := range envs {
+		env, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := fmt.Sprint(env["name"])
+		if value, ok := faultEnv[name]; ok {
+			env["value"] = value
+		}
+		if value, ok := env["value"].(string); ok && strings.HasSuffix(name, "_IMAGE") {
+			env["value"] = images.Rewrite(value)
+		}
+	}
+	if err := unstructured.SetNestedSlice(experiment, envs, "spec", "definition", "env"); err != nil {
+		return "", err
+	}
+	if image, _, _ := unstructured.NestedString(experiment, "spec", "definition", "image"); image != "" {
+		if err := unstructured.SetNestedField(experiment, images.Rewrite(image), "spec", "definition", "image"); err != nil {
+			return "", err
+		}
+	}
+
+	// The container runtime socket is mounted from the node
+	if fault.Type == ContainerKill && fault.Config.SocketPath != "" {
+		volumes, _, _ := unstructured.NestedSlice(experiment, "spec", "definition", "hostFileVolumes")
+		for _, item := range volumes {
+			if volume, ok := item.(map[string]interface{}); ok && volume["name"] == "socket-path" {
+				volume["mountPath"] = fault.Config.SocketPath
+				volume["nodePath"] = fault.Config.SocketPath
+			}
+		}
+		if err := unstructured.SetNestedSlice(experiment, volumes, "spec", "definition", "hostFileVolumes"); err != nil {
+			return "", err
+		}
+	}
+
+	data, err := yamlChe.Marshal(experiment)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the chaos experiment of %s: %v", fault.Type, err)
+	}
+	return string(data), nil
+}
+
+// marshalResource returns the YAML of a kubernetes resource without its status and empty fields
+func marshalResource(resource interface{}) (string, error) {
+	jsonBytes, err := json.Marshal(resource)
+	if err != nil {
+		return "", err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(jsonBytes, &obj); err != nil {
+		return "", err
+	}
+
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	pruneEmptyFields(obj)
+
+	data, err := yamlChe.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// marshalEngine returns the YAML of the ChaosEngine with the given probes inlined in its experiments
+func marshalEngine(engine *v1alpha1.ChaosEngine, inlineProbes []probe.Definition) (string, error) {
+	if len(inlineProbes) == 0 {
+		return marshalResource(engine)
+	}
+
+	jsonBytes, err := json.Marshal(engine)
+	if err != nil {
+		return "", err
+	}
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(jsonBytes, &obj); err != nil {
+		return "", err
+	}
+	if err := probe.SetEngineProbes(obj, inlineProbes); err != nil {
+		return "", err
+	}
+	return marshalResource(obj)
+}
+
+// pruneEmptyFields recursively removes the null values and empty objects of the given object
+func pruneEmptyFields(obj map[string]interface{}) {
+	for key, value := range obj {
+		switch v := value.(type) {
+		case nil:
+			delete(obj, key)
+		case map[string]interface{}:
+			pruneEmptyFields(v)
+			if len(v) == 0 {
+				delete(obj, key)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					pruneEmptyFields(m)
+				}
+			}
+		}
+	}
+}
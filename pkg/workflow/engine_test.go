@@ -0,0 +1,255 @@
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// renderEngine returns the ChaosEngine run by the given template of the workflow manifest
+func renderEngine(t *testing.T, manifest, templateName string) map[string]interface{} {
+	t.Helper()
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		t.Fatalf("failed to parse the workflow: %v", err)
+	}
+	for _, template := range workflow.Spec.Templates {
+		if template.Name != templateName {
+			continue
+		}
+		if template.Inputs == nil || len(template.Inputs.Artifacts) != 1 {
+			t.Fatalf("expected the template %s to have the engine artifact", templateName)
+		}
+		engine := map[string]interface{}{}
+		if err := yamlChe.Unmarshal([]byte(template.Inputs.Artifacts[0].Raw.Data), &engine); err != nil {
+			t.Fatalf("failed to parse the engine of %s: %v", templateName, err)
+		}
+		return engine
+	}
+	t.Fatalf("template %s not found in the workflow", templateName)
+	return nil
+}
+
+// probeRefAnnotation returns the probeRef annotation of the engine, or an empty string if it has none
+func probeRefAnnotation(engine map[string]interface{}) string {
+	metadata, _ := engine["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	probeRef, _ := annotations["probeRef"].(string)
+	return probeRef
+}
+
+func TestProbeRefAnnotation(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes string
+		config func(*ExperimentConfig)
+		want   string
+	}{
+		{
+			name: "legacy probe by default",
+			want: `[{"name":"myprobe","mode":"SOT"}]`,
+		},
+		{
+			name:   "probes from LITMUS_PROBES",
+			probes: "http-health:SOT,prom-errors:Continuous",
+			want:   `[{"name":"http-health","mode":"SOT"},{"name":"prom-errors","mode":"Continuous"}]`,
+		},
+		{
+			name: "probes from the config",
+			config: func(config *ExperimentConfig) {
+				config.Probes = []ProbeRef{{Name: "cmd-check", Mode: "EOT"}, {Name: "k8s-check"}}
+			},
+			want: `[{"name":"cmd-check","mode":"EOT"},{"name":"k8s-check","mode":"SOT"}]`,
+		},
+		{
+			name: "no probes",
+			config: func(config *ExperimentConfig) {
+				config.ProbeName = ""
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LITMUS_PROBES", tt.probes)
+			t.Setenv("LITMUS_USE_EXISTING_PROBE", "")
+
+			config := GetDefaultExperimentConfig(PodDelete)
+			ApplyProbeConfigFromEnv(&config)
+			if tt.config != nil {
+				tt.config(&config)
+			}
+			manifest, err := GetExperimentManifest(PodDelete, "pod-delete-test", config)
+			if err != nil {
+				t.Fatalf("failed to build the manifest: %v", err)
+			}
+
+			if got := probeRefAnnotation(renderEngine(t, manifest, "pod-delete-ce5")); got != tt.want {
+				t.Errorf("expected the probeRef annotation %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+// engineEnvNames returns the names of the env variables of the first experiment of the engine
+func engineEnvNames(t *testing.T, engine map[string]interface{}) []string {
+	t.Helper()
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	if len(experiments) != 1 {
+		t.Fatalf("expected the engine to have a single experiment, got %d", len(experiments))
+	}
+	env, _, _ := unstructured.NestedSlice(experiments[0].(map[string]interface{}), "spec", "components", "env")
+	var names []string
+	for _, item := range env {
+		names = append(names, item.(map[string]interface{})["name"].(string))
+	}
+	return names
+}
+
+func TestGetChaosEngine(t *testing.T) {
+	for _, name := range []string{"TARGET_NODE", "NODE_LABEL", "TAINTS", "TARGET_NODE_IP", "SSH_USER", "CHAOS_HUB_PATH", "LITMUS_PROBES"} {
+		t.Setenv(name, "")
+	}
+
+	tests := []struct {
+		experimentType ExperimentType
+		env            []string
+	}{
+		{PodDelete, []string{"TOTAL_CHAOS_DURATION", "FORCE", "CHAOS_INTERVAL"}},
+		{PodCPUHog, []string{"TOTAL_CHAOS_DURATION", "CPU_CORES"}},
+		{PodMemoryHog, []string{"TOTAL_CHAOS_DURATION", "MEMORY_CONSUMPTION"}},
+		{PodIOStress, []string{"TOTAL_CHAOS_DURATION", "FILESYSTEM_UTILIZATION_PERCENTAGE", "NUMBER_OF_WORKERS", "LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH", "SEQUENCE", "DEFAULT_HEALTH_CHECK"}},
+		{PodNetworkCorruption, []string{"NETWORK_INTERFACE", "LIB_IMAGE", "TC_IMAGE", "NETWORK_PACKET_CORRUPTION_PERCENTAGE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodNetworkLatency, []string{"NETWORK_INTERFACE", "LIB_IMAGE", "TC_IMAGE", "NETWORK_LATENCY", "JITTER", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodNetworkLoss, []string{"NETWORK_INTERFACE", "LIB_IMAGE", "TC_IMAGE", "NETWORK_PACKET_LOSS_PERCENTAGE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodNetworkDuplication, []string{"NETWORK_INTERFACE", "LIB_IMAGE", "TC_IMAGE", "NETWORK_PACKET_DUPLICATION_PERCENTAGE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodNetworkPartition, []string{"TOTAL_CHAOS_DURATION", "POLICY_TYPES", "DEFAULT_HEALTH_CHECK"}},
+		{PodNetworkRateLimit, []string{"NETWORK_INTERFACE", "LIB_IMAGE", "TC_IMAGE", "NETWORK_BANDWIDTH", "BURST", "LIMIT", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodHTTPLatency, []string{"LIB_IMAGE", "LATENCY", "TARGET_SERVICE_PORT", "PROXY_PORT", "TOXICITY", "NETWORK_INTERFACE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodHTTPStatusCode, []string{"LIB_IMAGE", "STATUS_CODE", "MODIFY_RESPONSE_BODY", "CONTENT_TYPE", "TARGET_SERVICE_PORT", "PROXY_PORT", "TOXICITY", "NETWORK_INTERFACE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodHTTPModifyHeader, []string{"LIB_IMAGE", "HEADERS_MAP", "HEADER_MODE", "TARGET_SERVICE_PORT", "PROXY_PORT", "TOXICITY", "NETWORK_INTERFACE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodHTTPModifyBody, []string{"LIB_IMAGE", "RESPONSE_BODY", "CONTENT_TYPE", "TARGET_SERVICE_PORT", "PROXY_PORT", "TOXICITY", "NETWORK_INTERFACE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodHTTPResetPeer, []string{"LIB_IMAGE", "RESET_TIMEOUT", "TARGET_SERVICE_PORT", "PROXY_PORT", "TOXICITY", "NETWORK_INTERFACE", "TOTAL_CHAOS_DURATION", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodDNSError, []string{"TOTAL_CHAOS_DURATION", "MATCH_SCHEME", "LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodDNSSpoof, []string{"TOTAL_CHAOS_DURATION", "SPOOF_MAP", "LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{PodAutoscaler, []string{"TOTAL_CHAOS_DURATION", "REPLICA_COUNT", "DEFAULT_HEALTH_CHECK"}},
+		{ContainerKill, []string{"CHAOS_INTERVAL", "SIGNAL", "SOCKET_PATH", "CONTAINER_RUNTIME", "TOTAL_CHAOS_DURATION", "DEFAULT_HEALTH_CHECK", "LIB_IMAGE", "SEQUENCE"}},
+		{DiskFill, []string{"FILL_PERCENTAGE", "TOTAL_CHAOS_DURATION", "DATA_BLOCK_SIZE", "DEFAULT_HEALTH_CHECK", "LIB_IMAGE", "SOCKET_PATH", "CONTAINER_RUNTIME", "SEQUENCE"}},
+		{NodeCPUHog, []string{"TOTAL_CHAOS_DURATION", "CPU_LOAD", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{NodeMemoryHog, []string{"TOTAL_CHAOS_DURATION", "MEMORY_CONSUMPTION_PERCENTAGE", "MEMORY_CONSUMPTION_MEBIBYTES", "NUMBER_OF_WORKERS", "DEFAULT_HEALTH_CHECK", "LIB_IMAGE", "SEQUENCE"}},
+		{NodeIOStress, []string{"TOTAL_CHAOS_DURATION", "FILESYSTEM_UTILIZATION_PERCENTAGE", "DEFAULT_HEALTH_CHECK", "SEQUENCE"}},
+		{NodeDrain, []string{"TARGET_NODE", "TOTAL_CHAOS_DURATION", "DEFAULT_HEALTH_CHECK"}},
+		{NodeTaint, []string{"TARGET_NODE", "TOTAL_CHAOS_DURATION", "TAINTS", "DEFAULT_HEALTH_CHECK"}},
+		{KubeletServiceKill, []string{"TOTAL_CHAOS_DURATION", "LIB_IMAGE", "TARGET_NODE", "DEFAULT_HEALTH_CHECK"}},
+		{NodeRestart, []string{"SSH_USER", "TOTAL_CHAOS_DURATION", "REBOOT_COMMAND", "TARGET_NODE", "LIB_IMAGE", "DEFAULT_HEALTH_CHECK"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.experimentType), func(t *testing.T) {
+			config := GetDefaultExperimentConfig(tt.experimentType)
+			if TargetsNode(tt.experimentType) {
+				config.TargetNode = "worker-1"
+			}
+			manifest, err := GetExperimentManifest(tt.experimentType, "engine-test", config)
+			if err != nil {
+				t.Fatalf("failed to build the manifest: %v", err)
+			}
+			engine := renderEngine(t, manifest, string(tt.experimentType)+"-ce5")
+
+			if names := engineEnvNames(t, engine); !reflect.DeepEqual(names, tt.env) {
+				t.Errorf("expected the env %v, got %v", tt.env, names)
+			}
+
+			annotationCheck, _, _ := unstructured.NestedString(engine, "spec", "annotationCheck")
+			appinfo, _, _ := unstructured.NestedStringMap(engine, "spec", "appinfo")
+			if isNodeExperiment(tt.experimentType) {
+				if annotationCheck != "false" || len(appinfo) != 0 {
+					t.Errorf("expected a node fault without appinfo and annotationCheck false, got appinfo %v and annotationCheck %q", appinfo, annotationCheck)
+				}
+			} else {
+				want := map[string]string{"appns": config.AppNamespace, "applabel": config.AppLabel, "appkind": config.AppKind}
+				if !reflect.DeepEqual(appinfo, want) || annotationCheck != "" {
+					t.Errorf("expected the appinfo %v without annotationCheck, got appinfo %v and annotationCheck %q", want, appinfo, annotationCheck)
+				}
+			}
+		})
+	}
+}
+
+func TestGetChaosEngineNodeLabel(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+
+	config := GetDefaultExperimentConfig(PodNetworkLoss)
+	config.NodeLabel = "pool=spot"
+	manifest, err := GetExperimentManifest(PodNetworkLoss, "engine-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	names := engineEnvNames(t, renderEngine(t, manifest, "pod-network-loss-ce5"))
+	if !pkg.ContainsString(names, "NODE_LABEL") {
+		t.Errorf("expected NODE_LABEL in the env once it is set, got %v", names)
+	}
+}
+
+func TestGetChaosEngineInlineProbes(t *testing.T) {
+	t.Setenv("CHAOS_HUB_PATH", "")
+
+	config := GetDefaultExperimentConfig(PodDelete)
+	config.InlineProbes = []probe.Definition{
+		{
+			Name:          "check-app",
+			Type:          "httpProbe",
+			RunProperties: probe.RunProperties{ProbeTimeout: "5s", Interval: "2s"},
+			HTTPInputs: &probe.HTTPInputs{
+				URL:    "http://app.default.svc:8080",
+				Method: probe.HTTPMethod{Get: &probe.HTTPGet{Criteria: "==", ResponseCode: "200"}},
+			},
+		},
+		{
+			Name:          "check-pods",
+			Type:          "k8sProbe",
+			Mode:          "EOT",
+			RunProperties: probe.RunProperties{ProbeTimeout: "5s", Interval: "2s"},
+			K8sInputs:     &probe.K8sInputs{Version: "v1", Resource: "pods", Operation: "present"},
+		},
+	}
+	manifest, err := GetExperimentManifest(PodDelete, "engine-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+
+	engine := renderEngine(t, manifest, "pod-delete-ce5")
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	probes, _, _ := unstructured.NestedSlice(experiments[0].(map[string]interface{}), "spec", "probe")
+	if len(probes) != 2 {
+		t.Fatalf("expected the 2 inline probes in the engine, got %d", len(probes))
+	}
+
+	// The required fields survive the pruning of the empty fields
+	fields := []struct {
+		probe int
+		path  []string
+		want  string
+	}{
+		{0, []string{"mode"}, "SOT"},
+		{0, []string{"runProperties", "probeTimeout"}, "5s"},
+		{0, []string{"runProperties", "interval"}, "2s"},
+		{0, []string{"httpProbe/inputs", "url"}, "http://app.default.svc:8080"},
+		{0, []string{"httpProbe/inputs", "method", "get", "criteria"}, "=="},
+		{0, []string{"httpProbe/inputs", "method", "get", "responseCode"}, "200"},
+		{1, []string{"mode"}, "EOT"},
+		{1, []string{"k8sProbe/inputs", "version"}, "v1"},
+		{1, []string{"k8sProbe/inputs", "resource"}, "pods"},
+		{1, []string{"k8sProbe/inputs", "operation"}, "present"},
+	}
+	for _, field := range fields {
+		got, _, _ := unstructured.NestedString(probes[field.probe].(map[string]interface{}), field.path...)
+		if got != field.want {
+			t.Errorf("expected %v of probe %d to be %q, got %q", field.path, field.probe, field.want, got)
+		}
+	}
+}
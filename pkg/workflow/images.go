@@ -0,0 +1,88 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// k8sImage runs kubectl to install the faults and to clean up
+	k8sImage = "litmuschaos/k8s:2.11.0"
+	// litmusCheckerImage creates the ChaosEngine and waits for its completion
+	litmusCheckerImage = "docker.io/litmuschaos/litmus-checker:2.11.0"
+	// defaultRunnerImage is the chaos-runner image used by the chaos-operator when the engine doesn't set one
+	defaultRunnerImage = "litmuschaos.docker.scarf.sh/litmuschaos/chaos-runner:3.16.0"
+)
+
+// ImageConfig rewrites the images of the generated workflow, so that they can be pulled from a private mirror
+type ImageConfig struct {
+	Registry    string            // Registry which replaces the registry of every image, like registry.internal:5000
+	Overrides   map[string]string // Images replaced as a whole, they take precedence over the registry
+	PullSecrets []string          // Secrets used to pull the images
+}
+
+// getImageConfig returns the image configuration set by IMAGE_REGISTRY, IMAGE_OVERRIDES and IMAGE_PULL_SECRETS.
+// IMAGE_OVERRIDES is a comma separated list of image=replacement pairs.
+func getImageConfig() ImageConfig {
+	images := ImageConfig{
+		Registry:  strings.TrimSuffix(getEnv("IMAGE_REGISTRY", ""), "/"),
+		Overrides: map[string]string{},
+	}
+	for _, pair := range environment.SplitList(getEnv("IMAGE_OVERRIDES", "")) {
+		if i := strings.Index(pair, "="); i > 0 {
+			images.Overrides[strings.TrimSpace(pair[:i])] = strings.TrimSpace(pair[i+1:])
+		}
+	}
+	images.PullSecrets = environment.SplitList(getEnv("IMAGE_PULL_SECRETS", ""))
+	return images
+}
+
+// Rewrite returns the image to pull instead of the given one
+func (c ImageConfig) Rewrite(image string) string {
+	if image == "" {
+		return image
+	}
+	if override, ok := c.Overrides[image]; ok {
+		return override
+	}
+	if c.Registry == "" {
+		return image
+	}
+	return c.Registry + "/" + stripRegistry(image)
+}
+
+// stripRegistry removes the registry host from the image reference, if it has one. The official
+// Docker Hub images, like ubuntu:16.04, keep the library namespace they are mirrored under.
+func stripRegistry(image string) string {
+	host, repository := "docker.io", image
+	if i := strings.Index(image, "/"); i != -1 {
+		if prefix := image[:i]; strings.ContainsAny(prefix, ".:") || prefix == "localhost" {
+			host, repository = prefix, image[i+1:]
+		}
+	}
+	if (host == "docker.io" || host == "index.docker.io") && !strings.Contains(repository, "/") {
+		return "library/" + repository
+	}
+	return repository
+}
+
+// rewriteEnv rewrites the images passed to the faults through env, like LIB_IMAGE and TC_IMAGE
+func (c ImageConfig) rewriteEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if strings.HasSuffix(env[i].Name, "_IMAGE") {
+			env[i].Value = c.Rewrite(env[i].Value)
+		}
+	}
+	return env
+}
+
+// pullSecrets returns the pull secrets as references for the pod specs
+func (c ImageConfig) pullSecrets() []corev1.LocalObjectReference {
+	var secrets []corev1.LocalObjectReference
+	for _, name := range c.PullSecrets {
+		secrets = append(secrets, corev1.LocalObjectReference{Name: name})
+	}
+	return secrets
+}
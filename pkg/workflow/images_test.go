@@ -0,0 +1,35 @@
+package workflow
+
+import "testing"
+
+func TestRewrite(t *testing.T) {
+	images := ImageConfig{
+		Registry:  "registry.internal:5000",
+		Overrides: map[string]string{"litmuschaos/k8s:2.11.0": "mirror.internal/k8s:2.11.0"},
+	}
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{image: "", want: ""},
+		{image: "litmuschaos/k8s:2.11.0", want: "mirror.internal/k8s:2.11.0"},
+		{image: "ubuntu:16.04", want: "registry.internal:5000/library/ubuntu:16.04"},
+		{image: "docker.io/ubuntu:16.04", want: "registry.internal:5000/library/ubuntu:16.04"},
+		{image: "gaiadocker/iproute2", want: "registry.internal:5000/gaiadocker/iproute2"},
+		{image: "docker.io/litmuschaos/litmus-checker:2.11.0", want: "registry.internal:5000/litmuschaos/litmus-checker:2.11.0"},
+		{image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0", want: "registry.internal:5000/litmuschaos/go-runner:3.16.0"},
+		{image: "localhost/app:latest", want: "registry.internal:5000/app:latest"},
+		{image: "localhost:5000/app:latest", want: "registry.internal:5000/app:latest"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			if got := images.Rewrite(tt.image); got != tt.want {
+				t.Errorf("expected %s to be rewritten to %s, got %s", tt.image, tt.want, got)
+			}
+		})
+	}
+
+	if got := (ImageConfig{}).Rewrite("ubuntu:16.04"); got != "ubuntu:16.04" {
+		t.Errorf("expected the image to be kept without a registry, got %s", got)
+	}
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+)
+
+// getApplicationNode returns the node of the application, replaced by the tests
+var getApplicationNode = defaultGetApplicationNode
+
+// defaultGetApplicationNode looks up the node of the application with the kubeconfig
+func defaultGetApplicationNode(appNamespace, appLabel string) (string, error) {
+	clients := environment.ClientSets{}
+	if err := clients.GenerateClientSetFromKubeConfig(); err != nil {
+		return "", fmt.Errorf("unable to get the kubeconfig to find the node of the application: %v", err)
+	}
+	return pkg.GetApplicationNode(appNamespace, appLabel, clients)
+}
+
+// WithTargetNode returns the configuration of a node lifecycle fault pointed at the node of the application,
+// as litmus would pick any node of the cluster otherwise. The configuration is returned as is for the other
+// faults, or if it already sets TargetNode or NodeLabel.
+func WithTargetNode(experimentType ExperimentType, config ExperimentConfig) (ExperimentConfig, error) {
+	if !TargetsNode(experimentType) || config.TargetNode != "" || config.NodeLabel != "" {
+		return config, nil
+	}
+	targetNode, err := getApplicationNode(config.AppNamespace, config.AppLabel)
+	if err != nil {
+		return config, fmt.Errorf("unable to find the target node of %s, set TARGET_NODE or NODE_LABEL: %v", experimentType, err)
+	}
+	log.Printf("Targeting node %s of the application %s with %s\n", targetNode, config.AppLabel, experimentType)
+	config.TargetNode = targetNode
+	return config, nil
+}
+
+// withTargetNodeInSteps returns a copy of the steps where the node lifecycle faults are pointed at the node of the application
+func withTargetNodeInSteps(steps []FaultStep) ([]FaultStep, error) {
+	var result []FaultStep
+	for _, step := range steps {
+		var faults FaultStep
+		for _, fault := range step {
+			config, err := WithTargetNode(fault.Type, fault.Config)
+			if err != nil {
+				return nil, err
+			}
+			fault.Config = config
+			faults = append(faults, fault)
+		}
+		result = append(result, faults)
+	}
+	return result, nil
+}
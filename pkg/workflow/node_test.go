@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithTargetNode(t *testing.T) {
+	getApplicationNode = func(appNamespace, appLabel string) (string, error) {
+		if appLabel == "app=unscheduled" {
+			return "", errors.New("no scheduled pods found")
+		}
+		return "worker-" + appNamespace, nil
+	}
+	defer func() { getApplicationNode = defaultGetApplicationNode }()
+
+	tests := []struct {
+		name           string
+		experimentType ExperimentType
+		config         ExperimentConfig
+		want           string
+		wantErr        string
+	}{
+		{
+			name:           "node of the application",
+			experimentType: NodeDrain,
+			config:         ExperimentConfig{AppNamespace: "shop", AppLabel: "app=cart"},
+			want:           "worker-shop",
+		},
+		{
+			name:           "target node set",
+			experimentType: NodeTaint,
+			config:         ExperimentConfig{AppNamespace: "shop", AppLabel: "app=cart", TargetNode: "worker-1"},
+			want:           "worker-1",
+		},
+		{
+			name:           "node label set",
+			experimentType: NodeRestart,
+			config:         ExperimentConfig{AppNamespace: "shop", AppLabel: "app=cart", NodeLabel: "pool=spot"},
+		},
+		{
+			name:           "not a node lifecycle fault",
+			experimentType: NodeCPUHog,
+			config:         ExperimentConfig{AppNamespace: "shop", AppLabel: "app=cart"},
+		},
+		{
+			name:           "application not scheduled",
+			experimentType: KubeletServiceKill,
+			config:         ExperimentConfig{AppNamespace: "shop", AppLabel: "app=unscheduled"},
+			wantErr:        "set TARGET_NODE or NODE_LABEL",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := WithTargetNode(tt.experimentType, tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to resolve the target node: %v", err)
+			}
+			if config.TargetNode != tt.want {
+				t.Errorf("expected the target node %q, got %q", tt.want, config.TargetNode)
+			}
+		})
+	}
+}
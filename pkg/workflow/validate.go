@@ -0,0 +1,354 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	yamlChe "github.com/ghodss/yaml"
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoshub"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// placeholderRegex matches the template placeholders like __CHAOS_DURATION_VALUE__
+var placeholderRegex = regexp.MustCompile(`__[A-Z][A-Z0-9_]*__`)
+
+// mandatoryEnv lists the env variables, besides TOTAL_CHAOS_DURATION, which need a value for the fault to run
+var mandatoryEnv = map[ExperimentType][]string{
+	PodCPUHog:             {"CPU_CORES"},
+	PodMemoryHog:          {"MEMORY_CONSUMPTION"},
+	PodIOStress:           {"LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH"},
+	PodNetworkCorruption:  {"NETWORK_INTERFACE", "TC_IMAGE", "LIB_IMAGE", "NETWORK_PACKET_CORRUPTION_PERCENTAGE"},
+	PodNetworkLatency:     {"NETWORK_INTERFACE", "TC_IMAGE", "LIB_IMAGE", "NETWORK_LATENCY"},
+	PodNetworkLoss:        {"NETWORK_INTERFACE", "TC_IMAGE", "LIB_IMAGE", "NETWORK_PACKET_LOSS_PERCENTAGE"},
+	PodNetworkDuplication: {"NETWORK_INTERFACE", "TC_IMAGE", "LIB_IMAGE", "NETWORK_PACKET_DUPLICATION_PERCENTAGE"},
+	PodNetworkPartition:   {"POLICY_TYPES"},
+	PodNetworkRateLimit:   {"NETWORK_INTERFACE", "TC_IMAGE", "LIB_IMAGE", "NETWORK_BANDWIDTH", "BURST", "LIMIT"},
+	PodHTTPLatency:        {"TARGET_SERVICE_PORT", "LIB_IMAGE", "LATENCY"},
+	PodHTTPStatusCode:     {"TARGET_SERVICE_PORT", "LIB_IMAGE"},
+	PodHTTPModifyHeader:   {"TARGET_SERVICE_PORT", "LIB_IMAGE", "HEADERS_MAP", "HEADER_MODE"},
+	PodHTTPModifyBody:     {"TARGET_SERVICE_PORT", "LIB_IMAGE", "RESPONSE_BODY"},
+	PodHTTPResetPeer:      {"TARGET_SERVICE_PORT", "LIB_IMAGE"},
+	PodDNSError:           {"LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH", "MATCH_SCHEME"},
+	PodDNSSpoof:           {"LIB_IMAGE", "CONTAINER_RUNTIME", "SOCKET_PATH", "SPOOF_MAP"},
+	PodAutoscaler:         {"REPLICA_COUNT"},
+	ContainerKill:         {"SIGNAL", "CONTAINER_RUNTIME", "SOCKET_PATH"},
+	NodeTaint:             {"TAINTS"},
+	KubeletServiceKill:    {"LIB_IMAGE"},
+	NodeRestart:           {"SSH_USER", "REBOOT_COMMAND", "LIB_IMAGE"},
+}
+
+// jsonEnv lists the env variables which hold a JSON list or map
+var jsonEnv = map[string]bool{
+	"HEADERS_MAP":      true,
+	"TARGET_HOSTNAMES": true,
+	"SPOOF_MAP":        true,
+}
+
+// enumEnv lists the accepted values of the env variables which only take a fixed set of values
+var enumEnv = map[string][]string{
+	"MATCH_SCHEME": {"exact", "substring"},
+	"HEADER_MODE":  {"request", "response"},
+	"POLICY_TYPES": {"ingress", "egress", "all"},
+}
+
+// ValidateManifest checks the workflow manifest before it is submitted to ChaosCenter. It fails on
+// unresolved placeholders, invalid YAML in the raw artifacts, empty mandatory env variables,
+// missing appinfo for the pod-level faults, out-of-range percentages, malformed JSON or unknown
+// enum env values and invalid probes. Only the fields filled from the templates are scanned for
+// placeholders, the env values of the ChaosEngines hold the tunables and the config.Env of the user
+// and are only checked where they kept the default of the hub fault.
+func ValidateManifest(manifest string) error {
+	var problems []string
+
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		return fmt.Errorf("invalid experiment manifest: %v", err)
+	}
+	leftovers := placeholderRegex.FindAllString(withoutRawArtifacts(manifest), -1)
+
+	// The ChaosExperiment env holds the defaults of the variables which aren't set in the ChaosEngine
+	experimentEnv := map[string]map[string]string{}
+	type engineArtifact struct {
+		template string
+		engine   map[string]interface{}
+	}
+	var engines []engineArtifact
+
+	for _, template := range workflow.Spec.Templates {
+		if template.Inputs == nil {
+			continue
+		}
+		for _, artifact := range template.Inputs.Artifacts {
+			if artifact.Raw == nil {
+				continue
+			}
+			obj := map[string]interface{}{}
+			if err := yamlChe.Unmarshal([]byte(artifact.Raw.Data), &obj); err != nil {
+				problems = append(problems, fmt.Sprintf("artifact %s of template %s is not valid YAML: %v", artifact.Name, template.Name, err))
+				leftovers = append(leftovers, placeholderRegex.FindAllString(artifact.Raw.Data, -1)...)
+				continue
+			}
+			switch obj["kind"] {
+			case "ChaosExperiment":
+				name, _, _ := unstructured.NestedString(obj, "metadata", "name")
+				envs, _, _ := unstructured.NestedSlice(obj, "spec", "definition", "env")
+				experimentEnv[name] = envToMap(envs, nil)
+				leftovers = append(leftovers, placeholderRegex.FindAllString(withoutExperimentEnv(obj), -1)...)
+			case "ChaosEngine":
+				engines = append(engines, engineArtifact{template: template.Name, engine: obj})
+				leftovers = append(leftovers, placeholderRegex.FindAllString(withoutEngineEnv(obj), -1)...)
+			default:
+				leftovers = append(leftovers, placeholderRegex.FindAllString(artifact.Raw.Data, -1)...)
+			}
+		}
+	}
+	if len(leftovers) != 0 {
+		problems = append(problems, fmt.Sprintf("unresolved placeholders: %s", strings.Join(uniqueSorted(leftovers), ", ")))
+	}
+
+	for _, artifact := range engines {
+		problems = append(problems, validateChaosEngine(artifact.template, artifact.engine, experimentEnv)...)
+	}
+
+	if len(problems) != 0 {
+		return fmt.Errorf("invalid experiment manifest:\n - %s", strings.Join(problems, "\n - "))
+	}
+	return nil
+}
+
+// validateChaosEngine returns the problems found in the ChaosEngine of the given template
+func validateChaosEngine(templateName string, engine map[string]interface{}, experimentEnv map[string]map[string]string) []string {
+	var problems []string
+	hub := getChaosHub()
+
+	problems = append(problems, validateProbes(templateName, engine)...)
+
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	for _, item := range experiments {
+		experiment, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(experiment, "name")
+		faultType := ExperimentType(name)
+
+		// Pod-level faults need to know the target application
+		hubFault, _ := hub.Get(name)
+		if hubFault == nil {
+			hubFault = &chaoshub.Fault{Name: name}
+		}
+		if targetsApplication(Fault{Type: faultType}, hubFault) {
+			appns, _, _ := unstructured.NestedString(engine, "spec", "appinfo", "appns")
+			applabel, _, _ := unstructured.NestedString(engine, "spec", "appinfo", "applabel")
+			if appns == "" || applabel == "" {
+				problems = append(problems, fmt.Sprintf("template %s: missing appinfo namespace or label for the pod-level fault %s", templateName, name))
+			}
+		}
+
+		// Values set in the engine override the defaults of the ChaosExperiment
+		envs, _, _ := unstructured.NestedSlice(experiment, "spec", "components", "env")
+		env := envToMap(envs, experimentEnv[name])
+
+		// The env values which kept the default of the hub fault come from its template
+		hubEnv := hubFaultEnv(hubFault)
+		var hubKeys []string
+		for key := range hubEnv {
+			hubKeys = append(hubKeys, key)
+		}
+		sort.Strings(hubKeys)
+		for _, key := range hubKeys {
+			if leftovers := placeholderRegex.FindAllString(hubEnv[key], -1); len(leftovers) != 0 && env[key] == hubEnv[key] {
+				problems = append(problems, fmt.Sprintf("template %s: env %s of fault %s has unresolved placeholders: %s", templateName, key, name, strings.Join(uniqueSorted(leftovers), ", ")))
+			}
+		}
+
+		// The node lifecycle faults would hit a random node
+		if TargetsNode(faultType) && env["TARGET_NODE"] == "" && env["NODE_LABEL"] == "" {
+			problems = append(problems, fmt.Sprintf("template %s: fault %s needs TARGET_NODE or NODE_LABEL to select its node", templateName, name))
+		}
+
+		for _, key := range append([]string{"TOTAL_CHAOS_DURATION"}, mandatoryEnv[faultType]...) {
+			if env[key] == "" {
+				problems = append(problems, fmt.Sprintf("template %s: mandatory env %s of fault %s is empty", templateName, key, name))
+			}
+		}
+
+		var keys []string
+		for key := range env {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if env[key] == "" {
+				continue
+			}
+			if isPercentageEnv(key) {
+				value, err := strconv.Atoi(env[key])
+				if err != nil || value < 0 || value > 100 {
+					problems = append(problems, fmt.Sprintf("template %s: env %s of fault %s must be a percentage between 0 and 100, got %q", templateName, key, name, env[key]))
+				}
+			}
+			if jsonEnv[key] && !json.Valid([]byte(env[key])) {
+				problems = append(problems, fmt.Sprintf("template %s: env %s of fault %s must be valid JSON, got %q", templateName, key, name, env[key]))
+			}
+			if values, ok := enumEnv[key]; ok && !pkg.ContainsString(values, env[key]) {
+				problems = append(problems, fmt.Sprintf("template %s: env %s of fault %s must be one of %s, got %q", templateName, key, name, strings.Join(values, ", "), env[key]))
+			}
+		}
+	}
+	return problems
+}
+
+// validateProbes returns the problems found in the probes attached to the ChaosEngine,
+// either referenced through the probeRef annotation or inlined in the experiments
+func validateProbes(templateName string, engine map[string]interface{}) []string {
+	var problems []string
+	names := map[string]bool{}
+	check := func(name, mode string) {
+		if name == "" {
+			problems = append(problems, fmt.Sprintf("template %s: probe without a name", templateName))
+			return
+		}
+		if names[name] {
+			problems = append(problems, fmt.Sprintf("template %s: probe %s is attached more than once", templateName, name))
+		}
+		names[name] = true
+		if !pkg.ContainsString(probe.Modes, mode) {
+			problems = append(problems, fmt.Sprintf("template %s: probe %s must have one of the %s modes, got %q", templateName, name, strings.Join(probe.Modes, ", "), mode))
+		}
+	}
+
+	if annotation, found, _ := unstructured.NestedString(engine, "metadata", "annotations", "probeRef"); found {
+		var probeRefs []ProbeRef
+		if err := json.Unmarshal([]byte(annotation), &probeRefs); err != nil {
+			problems = append(problems, fmt.Sprintf("template %s: invalid probeRef annotation: %v", templateName, err))
+		}
+		for _, probeRef := range probeRefs {
+			check(probeRef.Name, probeRef.Mode)
+		}
+	}
+
+	// The inline probes can't reuse the name of a referenced probe
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	for _, item := range experiments {
+		experiment, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		inlineProbes, _, _ := unstructured.NestedSlice(experiment, "spec", "probe")
+		for _, inlineProbe := range inlineProbes {
+			if p, ok := inlineProbe.(map[string]interface{}); ok {
+				name, _ := p["name"].(string)
+				mode, _ := p["mode"].(string)
+				check(name, mode)
+			}
+		}
+	}
+	return problems
+}
+
+// isPercentageEnv returns true if the env variable holds a percentage, like the TOXICITY of the HTTP faults.
+// FILL_PERCENTAGE is left out since disk-fill accepts values above 100 to force the eviction.
+func isPercentageEnv(key string) bool {
+	if key == "FILL_PERCENTAGE" {
+		return false
+	}
+	return strings.HasSuffix(key, "_PERC") || strings.HasSuffix(key, "_PERCENTAGE") || key == "CPU_LOAD" || key == "TOXICITY"
+}
+
+// withoutRawArtifacts returns the manifest without the raw artifacts of its templates, which are scanned on their own
+func withoutRawArtifacts(manifest string) string {
+	var workflow Workflow
+	if err := json.Unmarshal([]byte(manifest), &workflow); err != nil {
+		return manifest
+	}
+	for _, template := range workflow.Spec.Templates {
+		if template.Inputs == nil {
+			continue
+		}
+		for _, artifact := range template.Inputs.Artifacts {
+			if artifact.Raw != nil {
+				artifact.Raw.Data = ""
+			}
+		}
+	}
+	data, err := json.Marshal(workflow)
+	if err != nil {
+		return manifest
+	}
+	return string(data)
+}
+
+// withoutEngineEnv returns the ChaosEngine without the env values of its experiments, as YAML
+func withoutEngineEnv(engine map[string]interface{}) string {
+	engine = runtime.DeepCopyJSON(engine)
+	experiments, _, _ := unstructured.NestedSlice(engine, "spec", "experiments")
+	for _, item := range experiments {
+		if experiment, ok := item.(map[string]interface{}); ok {
+			unstructured.RemoveNestedField(experiment, "spec", "components", "env")
+		}
+	}
+	_ = unstructured.SetNestedSlice(engine, experiments, "spec", "experiments")
+	data, _ := yamlChe.Marshal(engine)
+	return string(data)
+}
+
+// withoutExperimentEnv returns the ChaosExperiment as YAML without its env, the env values are
+// checked against the hub fault since they hold the overrides of the user
+func withoutExperimentEnv(experiment map[string]interface{}) string {
+	experiment = runtime.DeepCopyJSON(experiment)
+	unstructured.RemoveNestedField(experiment, "spec", "definition", "env")
+	data, _ := yamlChe.Marshal(experiment)
+	return string(data)
+}
+
+// hubFaultEnv returns the default env of the hub fault, the ChaosExperiment env overridden by the sample ChaosEngine
+func hubFaultEnv(hubFault *chaoshub.Fault) map[string]string {
+	experiment := map[string]interface{}{}
+	_ = yamlChe.Unmarshal(hubFault.Experiment, &experiment)
+	envs, _, _ := unstructured.NestedSlice(experiment, "spec", "definition", "env")
+	env := envToMap(envs, nil)
+
+	engineEnv, _ := hubFault.EngineEnv()
+	for _, envVar := range engineEnv {
+		env[envVar.Name] = envVar.Value
+	}
+	return env
+}
+
+// envToMap converts an env list to a map on top of the given defaults
+func envToMap(envs []interface{}, defaults map[string]string) map[string]string {
+	env := map[string]string{}
+	for key, value := range defaults {
+		env[key] = value
+	}
+	for _, item := range envs {
+		if e, ok := item.(map[string]interface{}); ok {
+			name, _ := e["name"].(string)
+			value, _ := e["value"].(string)
+			env[name] = value
+		}
+	}
+	return env
+}
+
+// uniqueSorted returns the sorted unique values of the list
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeHubFault writes a fault to a ChaosHub checkout in a temporary directory and points CHAOS_HUB_PATH to it
+func writeHubFault(t *testing.T, name, experiment, engine string) {
+	t.Helper()
+	hubPath := t.TempDir()
+	faultDir := filepath.Join(hubPath, "faults", "kubernetes", name)
+	if err := os.MkdirAll(faultDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(faultDir, "fault.yaml"), []byte(experiment), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(faultDir, "engine.yaml"), []byte(engine), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CHAOS_HUB_PATH", hubPath)
+}
+
+const demoFaultExperiment = `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosExperiment
+metadata:
+  name: demo-fault
+spec:
+  definition:
+    image: litmuschaos/go-runner:latest
+    env:
+    - name: TOTAL_CHAOS_DURATION
+      value: "30"
+`
+
+const demoFaultEngine = `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  name: demo
+spec:
+  experiments:
+  - name: demo-fault
+    spec:
+      components:
+        env:
+        - name: TARGET_VALUE
+          value: __DEMO_TARGET_VALUE__
+`
+
+func TestValidateManifestAcceptsUserValuesLikePlaceholders(t *testing.T) {
+	config := GetDefaultExperimentConfig(PodDelete)
+	config.Env = map[string]string{"CUSTOM_MARKER": "__USER_MARKER__"}
+
+	manifest, err := GetExperimentManifest(PodDelete, "pod-delete-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	if !strings.Contains(manifest, "__USER_MARKER__") {
+		t.Fatalf("the env value of the user is missing from the manifest")
+	}
+	if err := ValidateManifest(manifest); err != nil {
+		t.Errorf("expected the manifest to be valid, got: %v", err)
+	}
+}
+
+func TestValidateManifestRejectsUnfilledHubPlaceholders(t *testing.T) {
+	writeHubFault(t, "demo-fault", demoFaultExperiment, demoFaultEngine)
+
+	manifest, err := GetExperimentManifest("demo-fault", "demo-fault-test", GetDefaultExperimentConfig("demo-fault"))
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	err = ValidateManifest(manifest)
+	if err == nil || !strings.Contains(err.Error(), "__DEMO_TARGET_VALUE__") {
+		t.Errorf("expected the unfilled placeholder of the hub fault to be reported, got: %v", err)
+	}
+
+	config := GetDefaultExperimentConfig("demo-fault")
+	config.Env = map[string]string{"TARGET_VALUE": "__OVERRIDDEN_BY_USER__"}
+	manifest, err = GetExperimentManifest("demo-fault", "demo-fault-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	if err := ValidateManifest(manifest); err != nil {
+		t.Errorf("expected the value set by the user to be accepted, got: %v", err)
+	}
+}
+
+func TestValidateManifestAcceptsUserValuesInExperimentEnv(t *testing.T) {
+	config := GetDefaultExperimentConfig(ContainerKill)
+	config.Env = map[string]string{"TARGET_CONTAINER": "__X__"}
+
+	manifest, err := GetExperimentManifest(ContainerKill, "container-kill-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	if strings.Count(manifest, "__X__") < 2 {
+		t.Fatalf("expected the env value of the user in both the ChaosExperiment and the ChaosEngine")
+	}
+	if err := ValidateManifest(manifest); err != nil {
+		t.Errorf("expected the manifest to be valid, got: %v", err)
+	}
+}
+
+func TestValidateManifestRejectsUnfilledExperimentPlaceholders(t *testing.T) {
+	experiment := demoFaultExperiment + `    - name: TARGET_MODE
+      value: __DEMO_TARGET_MODE__
+`
+	writeHubFault(t, "demo-fault", experiment, demoFaultEngine)
+
+	config := GetDefaultExperimentConfig("demo-fault")
+	config.Env = map[string]string{"TARGET_VALUE": "set"}
+	manifest, err := GetExperimentManifest("demo-fault", "demo-fault-test", config)
+	if err != nil {
+		t.Fatalf("failed to build the manifest: %v", err)
+	}
+	err = ValidateManifest(manifest)
+	if err == nil || !strings.Contains(err.Error(), "__DEMO_TARGET_MODE__") {
+		t.Errorf("expected the unfilled placeholder of the ChaosExperiment to be reported, got: %v", err)
+	}
+}
@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 
+	"github.com/litmuschaos/chaos-ci-lib/pkg"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoscenter"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoshub"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
 	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
-	probe "github.com/litmuschaos/litmus-go-sdk/pkg/apis/probe"
 	"github.com/litmuschaos/litmus-go-sdk/pkg/sdk"
 	models "github.com/litmuschaos/litmus/chaoscenter/graphql/server/graph/model"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ExperimentType defines the available chaos experiment types
@@ -23,12 +27,26 @@ const (
 	PodDelete    ExperimentType = "pod-delete"
 	PodCPUHog    ExperimentType = "pod-cpu-hog"
 	PodMemoryHog ExperimentType = "pod-memory-hog"
+	PodIOStress  ExperimentType = "pod-io-stress"
 
 	// Network chaos
 	PodNetworkCorruption  ExperimentType = "pod-network-corruption"
 	PodNetworkLatency     ExperimentType = "pod-network-latency"
 	PodNetworkLoss        ExperimentType = "pod-network-loss"
 	PodNetworkDuplication ExperimentType = "pod-network-duplication"
+	PodNetworkPartition   ExperimentType = "pod-network-partition"
+	PodNetworkRateLimit   ExperimentType = "pod-network-rate-limit"
+
+	// HTTP chaos
+	PodHTTPLatency      ExperimentType = "pod-http-latency"
+	PodHTTPStatusCode   ExperimentType = "pod-http-status-code"
+	PodHTTPModifyHeader ExperimentType = "pod-http-modify-header"
+	PodHTTPModifyBody   ExperimentType = "pod-http-modify-body"
+	PodHTTPResetPeer    ExperimentType = "pod-http-reset-peer"
+
+	// DNS chaos
+	PodDNSError ExperimentType = "pod-dns-error"
+	PodDNSSpoof ExperimentType = "pod-dns-spoof"
 
 	// Autoscaling
 	PodAutoscaler ExperimentType = "pod-autoscaler"
@@ -43,6 +61,12 @@ const (
 	NodeCPUHog    ExperimentType = "node-cpu-hog"
 	NodeMemoryHog ExperimentType = "node-memory-hog"
 	NodeIOStress  ExperimentType = "node-io-stress"
+
+	// Node lifecycle chaos
+	NodeDrain          ExperimentType = "node-drain"
+	NodeTaint          ExperimentType = "node-taint"
+	KubeletServiceKill ExperimentType = "kubelet-service-kill"
+	NodeRestart        ExperimentType = "node-restart"
 )
 
 // ExperimentConfig holds configuration for an experiment
@@ -68,6 +92,11 @@ type ExperimentConfig struct {
 	// Memory hog specific parameters
 	MemoryConsumption string
 
+	// IO stress specific parameters
+	FilesystemUtilizationPercentage string
+	FilesystemUtilizationBytes      string
+	VolumeMountPath                 string
+
 	// Node memory hog specific parameters
 	MemoryConsumptionPercentage string
 	MemoryConsumptionMebibytes  string
@@ -97,6 +126,63 @@ type ExperimentConfig struct {
 	// Network duplication specific
 	NetworkPacketDuplicationPercentage string
 
+	// Network partition specific
+	PolicyTypes       string // ingress, egress or all
+	NamespaceSelector string // Labels of the destination namespaces, like env=prod
+	PodSelector       string // Labels of the destination pods, like app=db
+	Ports             string // Destination ports, like ingress=tcp:[1234,22];egress=udp:[53]
+
+	// Network rate limit specific
+	NetworkBandwidth string
+	Burst            string
+	Limit            string
+	MinBurst         string
+	PeakRate         string
+	SourcePorts      string
+	DestinationPorts string
+
+	// HTTP chaos common parameters
+	TargetServicePort string
+	ProxyPort         string
+	Toxicity          string
+
+	// HTTP latency specific
+	Latency string
+
+	// HTTP status code specific
+	StatusCode         string
+	ModifyResponseBody string
+
+	// HTTP modify body specific, also used by the status code fault
+	ResponseBody    string
+	ContentEncoding string
+	ContentType     string
+
+	// HTTP modify header specific
+	HeadersMap string
+	HeaderMode string
+
+	// HTTP reset peer specific
+	ResetTimeout string
+
+	// DNS error specific
+	TargetHostnames string // JSON list of the hostnames to fail, all the hostnames if empty
+	MatchScheme     string // exact or substring match of the target hostnames
+
+	// DNS spoof specific
+	SpoofMap string // JSON map of the hostnames to spoof and their replacement
+
+	// Node lifecycle common parameters
+	TargetNode string // Name of the node to inject the fault in, the experiment requests set it to the node of the application if it and NodeLabel are empty
+
+	// Node taint specific
+	Taints string // Taints to apply, like node.kubernetes.io/unreachable:NoExecute
+
+	// Node restart specific
+	TargetNodeIP  string
+	SSHUser       string
+	RebootCommand string
+
 	// Pod Autoscaler specific
 	ReplicaCount string
 
@@ -108,10 +194,18 @@ type ExperimentConfig struct {
 	DataBlockSize             string
 	EphemeralStorageMebibytes string
 
+	// Env overrides the ChaosEngine env of the fault, it is the only way
+	// to tune the faults which are resolved dynamically from the chaoshub
+	Env map[string]string
+
 	// Probe configuration
 	UseExistingProbe bool
 	ProbeName        string
 	ProbeMode        string
+	Probes           []ProbeRef // Probes attached instead of ProbeName, each one with its own mode
+
+	// InlineProbes are rendered in the ChaosEngine, they don't need to exist in ChaosCenter
+	InlineProbes []probe.Definition
 }
 
 // Getenv helper function to get environment variables with default values
@@ -123,6 +217,29 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getChaosHub returns the chaoshub checkout set by CHAOS_HUB_PATH, or the bundled faults if it isn't set
+func getChaosHub() *chaoshub.Hub {
+	return chaoshub.New(getEnv("CHAOS_HUB_PATH", ""))
+}
+
+// hubLibImage returns the LIB_IMAGE of the sample engine of the hub fault, or the fallback if the hub has no value for it
+func hubLibImage(experimentType ExperimentType, fallback string) string {
+	fault, err := getChaosHub().Get(string(experimentType))
+	if err != nil {
+		return fallback
+	}
+	env, err := fault.EngineEnv()
+	if err != nil {
+		return fallback
+	}
+	for _, envVar := range env {
+		if envVar.Name == "LIB_IMAGE" && envVar.Value != "" && !placeholderRegex.MatchString(envVar.Value) {
+			return envVar.Value
+		}
+	}
+	return fallback
+}
+
 // GetDefaultExperimentConfig returns default configuration for a given experiment type
 func GetDefaultExperimentConfig(experimentType ExperimentType) ExperimentConfig {
 	// Base config with common defaults - reading from environment variables
@@ -145,15 +262,33 @@ func GetDefaultExperimentConfig(experimentType ExperimentType) ExperimentConfig
 	if isNetworkExperiment(experimentType) {
 		config.NetworkInterface = "eth0"
 		config.TCImage = "gaiadocker/iproute2"
-		config.LibImage = "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
+		config.LibImage = hubLibImage(experimentType, defaultLibImage)
 		config.ContainerRuntime = "containerd"
-		config.SocketPath = "/run/containerd/containerd.sock"
+		config.SocketPath = defaultSocketPath
 		config.DestinationIPs = ""
 		config.DestinationHosts = ""
 		config.NodeLabel = ""
 		config.TargetPods = ""
 	}
 
+	// Set HTTP experiment common defaults
+	if isHTTPExperiment(experimentType) {
+		config.NetworkInterface = "eth0"
+		config.LibImage = hubLibImage(experimentType, defaultLibImage)
+		config.ContainerRuntime = "containerd"
+		config.SocketPath = defaultSocketPath
+		config.TargetServicePort = getEnv("TARGET_SERVICE_PORT", "80")
+		config.ProxyPort = "20000"
+		config.Toxicity = "100"
+	}
+
+	// Set DNS experiment common defaults
+	if isDNSExperiment(experimentType) {
+		config.LibImage = hubLibImage(experimentType, defaultLibImage)
+		config.ContainerRuntime = "containerd"
+		config.SocketPath = defaultSocketPath
+	}
+
 	// Apply experiment-specific defaults
 	switch experimentType {
 	case PodDelete:
@@ -176,6 +311,16 @@ func GetDefaultExperimentConfig(experimentType ExperimentType) ExperimentConfig
 		config.Description = "Pod memory hog chaos experiment execution"
 		config.Tags = []string{"pod-memory-hog", "chaos", "litmus"}
 
+	case PodIOStress:
+		config.ChaosDuration = "120"
+		config.FilesystemUtilizationPercentage = "10"
+		config.NumberOfWorkers = "4"
+		config.LibImage = hubLibImage(experimentType, defaultLibImage)
+		config.ContainerRuntime = "containerd"
+		config.SocketPath = defaultSocketPath
+		config.Description = "Pod IO stress chaos experiment execution"
+		config.Tags = []string{"pod-io-stress", "chaos", "litmus"}
+
 	case PodNetworkCorruption:
 		config.ChaosDuration = "60"
 		config.NetworkPacketCorruptionPercentage = "100"
@@ -201,6 +346,70 @@ func GetDefaultExperimentConfig(experimentType ExperimentType) ExperimentConfig
 		config.Description = "Pod network duplication chaos experiment execution"
 		config.Tags = []string{"pod-network-duplication", "network-chaos", "litmus"}
 
+	case PodNetworkPartition:
+		config.ChaosDuration = "60"
+		config.PolicyTypes = getEnv("POLICY_TYPES", "all")
+		config.NamespaceSelector = getEnv("NAMESPACE_SELECTOR", "")
+		config.PodSelector = getEnv("POD_SELECTOR", "")
+		config.Ports = getEnv("PORTS", "")
+		config.Description = "Pod network partition chaos experiment execution"
+		config.Tags = []string{"pod-network-partition", "network-chaos", "litmus"}
+
+	case PodNetworkRateLimit:
+		config.ChaosDuration = "60"
+		config.NetworkBandwidth = "1mbit"
+		config.Burst = "32kb"
+		config.Limit = "2mb"
+		config.Description = "Pod network rate limit chaos experiment execution"
+		config.Tags = []string{"pod-network-rate-limit", "network-chaos", "litmus"}
+
+	case PodHTTPLatency:
+		config.ChaosDuration = "60"
+		config.Latency = "2000"
+		config.Description = "Pod HTTP latency chaos experiment execution"
+		config.Tags = []string{"pod-http-latency", "http-chaos", "litmus"}
+
+	case PodHTTPStatusCode:
+		config.ChaosDuration = "60"
+		config.StatusCode = "500"
+		config.ModifyResponseBody = "true"
+		config.ContentType = "text/plain"
+		config.Description = "Pod HTTP status code chaos experiment execution"
+		config.Tags = []string{"pod-http-status-code", "http-chaos", "litmus"}
+
+	case PodHTTPModifyHeader:
+		config.ChaosDuration = "60"
+		config.HeadersMap = `{"X-Litmus-Chaos":"true"}`
+		config.HeaderMode = "response"
+		config.Description = "Pod HTTP modify header chaos experiment execution"
+		config.Tags = []string{"pod-http-modify-header", "http-chaos", "litmus"}
+
+	case PodHTTPModifyBody:
+		config.ChaosDuration = "60"
+		config.ResponseBody = "chaos injected by litmus"
+		config.ContentType = "text/plain"
+		config.Description = "Pod HTTP modify body chaos experiment execution"
+		config.Tags = []string{"pod-http-modify-body", "http-chaos", "litmus"}
+
+	case PodHTTPResetPeer:
+		config.ChaosDuration = "60"
+		config.ResetTimeout = "0"
+		config.Description = "Pod HTTP reset peer chaos experiment execution"
+		config.Tags = []string{"pod-http-reset-peer", "http-chaos", "litmus"}
+
+	case PodDNSError:
+		config.ChaosDuration = "60"
+		config.TargetHostnames = getEnv("TARGET_HOSTNAMES", "")
+		config.MatchScheme = getEnv("MATCH_SCHEME", "exact")
+		config.Description = "Pod DNS error chaos experiment execution"
+		config.Tags = []string{"pod-dns-error", "dns-chaos", "litmus"}
+
+	case PodDNSSpoof:
+		config.ChaosDuration = "60"
+		config.SpoofMap = getEnv("SPOOF_MAP", `{"litmuschaos.io":"example.com"}`)
+		config.Description = "Pod DNS spoof chaos experiment execution"
+		config.Tags = []string{"pod-dns-spoof", "dns-chaos", "litmus"}
+
 	case PodAutoscaler:
 		config.ChaosDuration = "60"
 		config.ReplicaCount = "5"
@@ -243,6 +452,45 @@ func GetDefaultExperimentConfig(experimentType ExperimentType) ExperimentConfig
 		config.ChaosDuration = "60"
 		config.Description = "Node IO stress chaos experiment execution"
 		config.Tags = []string{"node-io-stress", "chaos", "litmus"}
+
+	case NodeDrain:
+		config.ChaosDuration = "60"
+		config.TargetNode = getEnv("TARGET_NODE", "")
+		config.NodeLabel = getEnv("NODE_LABEL", "")
+		config.Description = "Node drain chaos experiment execution"
+		config.Tags = []string{"node-drain", "chaos", "litmus"}
+
+	case NodeTaint:
+		config.ChaosDuration = "60"
+		config.TargetNode = getEnv("TARGET_NODE", "")
+		config.NodeLabel = getEnv("NODE_LABEL", "")
+		config.Taints = getEnv("TAINTS", "node.kubernetes.io/unreachable:NoExecute")
+		config.Description = "Node taint chaos experiment execution"
+		config.Tags = []string{"node-taint", "chaos", "litmus"}
+
+	case KubeletServiceKill:
+		config.ChaosDuration = "60"
+		config.TargetNode = getEnv("TARGET_NODE", "")
+		config.NodeLabel = getEnv("NODE_LABEL", "")
+		config.LibImage = hubLibImage(experimentType, "ubuntu:16.04")
+		config.Description = "Kubelet service kill chaos experiment execution"
+		config.Tags = []string{"kubelet-service-kill", "chaos", "litmus"}
+
+	case NodeRestart:
+		config.ChaosDuration = "120"
+		config.TargetNode = getEnv("TARGET_NODE", "")
+		config.NodeLabel = getEnv("NODE_LABEL", "")
+		config.TargetNodeIP = getEnv("TARGET_NODE_IP", "")
+		config.SSHUser = getEnv("SSH_USER", "root")
+		config.RebootCommand = `-o ServerAliveInterval=1 -o ServerAliveCountMax=1 "sudo systemctl reboot"`
+		config.LibImage = hubLibImage(experimentType, defaultLibImage)
+		config.Description = "Node restart chaos experiment execution"
+		config.Tags = []string{"node-restart", "chaos", "litmus"}
+
+	default:
+		// Faults resolved from the chaoshub take their tunables from Env
+		config.Description = string(experimentType) + " chaos experiment execution"
+		config.Tags = []string{string(experimentType), "chaos", "litmus"}
 	}
 
 	return config
@@ -253,17 +501,48 @@ func isNetworkExperiment(experimentType ExperimentType) bool {
 	return experimentType == PodNetworkCorruption ||
 		experimentType == PodNetworkLatency ||
 		experimentType == PodNetworkLoss ||
-		experimentType == PodNetworkDuplication
+		experimentType == PodNetworkDuplication ||
+		experimentType == PodNetworkRateLimit
+}
+
+// isHTTPExperiment returns true if the experiment type is an HTTP experiment
+func isHTTPExperiment(experimentType ExperimentType) bool {
+	return experimentType == PodHTTPLatency ||
+		experimentType == PodHTTPStatusCode ||
+		experimentType == PodHTTPModifyHeader ||
+		experimentType == PodHTTPModifyBody ||
+		experimentType == PodHTTPResetPeer
+}
+
+// isDNSExperiment returns true if the experiment type is a DNS experiment
+func isDNSExperiment(experimentType ExperimentType) bool {
+	return experimentType == PodDNSError ||
+		experimentType == PodDNSSpoof
 }
 
-// ConstructExperimentRequest creates an Argo Workflow manifest for LitmusChaos
+// ConstructExperimentRequest creates an Argo Workflow manifest for LitmusChaos.
+// The node lifecycle faults without a TargetNode or NodeLabel are pointed at the node of the application, which needs a kubeconfig.
 func ConstructExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string, experimentType ExperimentType, config ExperimentConfig) (*models.SaveChaosExperimentRequest, error) {
+	inlineProbes, err := loadInlineProbes(details)
+	if err != nil {
+		return nil, err
+	}
+	config = withInlineProbes(config, inlineProbes)
+	if config, err = WithTargetNode(experimentType, config); err != nil {
+		return nil, err
+	}
+
 	// Get base workflow manifest for the experiment type
 	manifest, err := GetExperimentManifest(experimentType, experimentName, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get experiment manifest: %v", err)
 	}
 
+	// Catch the configuration mistakes before they reach the cluster
+	if err := ValidateManifest(manifest); err != nil {
+		return nil, err
+	}
+
 	// Construct the experiment request
 	experimentRequest := &models.SaveChaosExperimentRequest{
 		ID:          experimentID,
@@ -277,1925 +556,388 @@ func ConstructExperimentRequest(details *types.ExperimentDetails, experimentID s
 	return experimentRequest, nil
 }
 
-// GetExperimentManifest returns the complete workflow manifest string for a given experiment type
-func GetExperimentManifest(experimentType ExperimentType, experimentName string, config ExperimentConfig) (string, error) {
-	// Base workflow structure that's common for all experiments
-	baseManifest := map[string]interface{}{
-		"apiVersion": "argoproj.io/v1alpha1",
-		"kind":       "Workflow",
-		"metadata": map[string]interface{}{
-			"name":      experimentName,
-			"namespace": "litmus",
-		},
-		"spec": map[string]interface{}{
-			"entrypoint":         string(experimentType) + "-engine",
-			"serviceAccountName": "argo-chaos",
-			"podGC": map[string]string{
-				"strategy": "OnWorkflowCompletion",
-			},
-			"securityContext": map[string]interface{}{
-				"runAsUser":    1000,
-				"runAsNonRoot": true,
-			},
-			"arguments": map[string]interface{}{
-				"parameters": []map[string]string{
-					{
-						"name":  "adminModeNamespace",
-						"value": "litmus",
-					},
-				},
-			},
-			"templates": []map[string]interface{}{
-				// Main workflow template
-				{
-					"name": string(experimentType) + "-engine",
-					"steps": [][]map[string]interface{}{
-						{
-							{
-								"name":      "install-chaos-faults",
-								"template":  "install-chaos-faults",
-								"arguments": map[string]interface{}{},
-							},
-						},
-						{
-							{
-								"name":      string(experimentType) + "-ce5",
-								"template":  string(experimentType) + "-ce5",
-								"arguments": map[string]interface{}{},
-							},
-						},
-						{
-							{
-								"name":      "cleanup-chaos-resources",
-								"template":  "cleanup-chaos-resources",
-								"arguments": map[string]interface{}{},
-							},
-						},
-					},
-				},
-			},
-		},
-		"status": map[string]interface{}{},
+// loadInlineProbes returns the probe definitions set by LITMUS_INLINE_PROBES
+func loadInlineProbes(details *types.ExperimentDetails) ([]probe.Definition, error) {
+	if len(details.InlineProbePaths) == 0 {
+		return nil, nil
 	}
-
-	// Add experiment-specific templates
-	templates, err := getExperimentTemplates(experimentType, config)
+	inlineProbes, err := probe.LoadAll(details.InlineProbePaths)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to load the inline probes: %v", err)
 	}
+	return inlineProbes, nil
+}
 
-	// Append templates to the base manifest
-	baseManifest["spec"].(map[string]interface{})["templates"] = append(
-		baseManifest["spec"].(map[string]interface{})["templates"].([]map[string]interface{}),
-		templates...,
-	)
+// withInlineProbes returns the configuration with the given inline probes, unless it already has its own
+func withInlineProbes(config ExperimentConfig, inlineProbes []probe.Definition) ExperimentConfig {
+	if len(config.InlineProbes) == 0 {
+		config.InlineProbes = inlineProbes
+	}
+	return config
+}
 
-	// Convert to JSON and then to pretty-printed string
-	jsonBytes, err := json.MarshalIndent(baseManifest, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal workflow manifest: %v", err)
+// GetExperimentManifest returns the complete workflow manifest string for a given experiment type
+func GetExperimentManifest(experimentType ExperimentType, experimentName string, config ExperimentConfig) (string, error) {
+	steps := []FaultStep{{{Type: experimentType, Config: config}}}
+	return buildWorkflowManifest(experimentName, string(experimentType)+"-engine", steps)
+}
+
+// buildWorkflowManifest returns the workflow manifest which installs the faults, runs the
+// given steps one after the other (faults within a step run in parallel) and cleans up
+func buildWorkflowManifest(experimentName string, entrypoint string, steps []FaultStep) (string, error) {
+	if len(steps) == 0 {
+		return "", fmt.Errorf("no faults provided for experiment %s", experimentName)
 	}
 
-	// Convert JSON to string
-	manifestStr := string(jsonBytes)
-
-	// Replace with specific placeholders
-	manifestStr = strings.ReplaceAll(manifestStr, "__EXPERIMENT_NAME__", experimentName)
-	manifestStr = strings.ReplaceAll(manifestStr, "__EXPERIMENT_TYPE__", string(experimentType))
-	manifestStr = strings.ReplaceAll(manifestStr, "__APP_NAMESPACE__", config.AppNamespace)
-	manifestStr = strings.ReplaceAll(manifestStr, "__APP_LABEL__", config.AppLabel)
-	manifestStr = strings.ReplaceAll(manifestStr, "__APP_KIND__", config.AppKind)
-	manifestStr = strings.ReplaceAll(manifestStr, "__CHAOS_DURATION_VALUE__", config.ChaosDuration)
-	manifestStr = strings.ReplaceAll(manifestStr, "__CHAOS_INTERVAL_VALUE__", config.ChaosInterval)
-	manifestStr = strings.ReplaceAll(manifestStr, "__TARGET_CONTAINER_VALUE__", config.TargetContainer)
-	manifestStr = strings.ReplaceAll(manifestStr, "__PODS_AFFECTED_PERC_VALUE__", config.PodsAffectedPerc)
-	manifestStr = strings.ReplaceAll(manifestStr, "__RAMP_TIME_VALUE__", config.RampTime)
-	manifestStr = strings.ReplaceAll(manifestStr, "__TARGET_PODS_VALUE__", config.TargetPods)
-	manifestStr = strings.ReplaceAll(manifestStr, "__DEFAULT_HEALTH_CHECK_VALUE__", config.DefaultHealthCheck)
-
-	// Replace network experiment specific placeholders
-	if isNetworkExperiment(experimentType) {
-		manifestStr = strings.ReplaceAll(manifestStr, "__NETWORK_INTERFACE_VALUE__", config.NetworkInterface)
-		manifestStr = strings.ReplaceAll(manifestStr, "__TC_IMAGE_VALUE__", config.TCImage)
-		manifestStr = strings.ReplaceAll(manifestStr, "__LIB_IMAGE_VALUE__", config.LibImage)
-		manifestStr = strings.ReplaceAll(manifestStr, "__CONTAINER_RUNTIME_VALUE__", config.ContainerRuntime)
-		manifestStr = strings.ReplaceAll(manifestStr, "__SOCKET_PATH_VALUE__", config.SocketPath)
-		manifestStr = strings.ReplaceAll(manifestStr, "__DESTINATION_IPS_VALUE__", config.DestinationIPs)
-		manifestStr = strings.ReplaceAll(manifestStr, "__DESTINATION_HOSTS_VALUE__", config.DestinationHosts)
-		manifestStr = strings.ReplaceAll(manifestStr, "__SEQUENCE_VALUE__", config.Sequence)
-
-		// For network tests, completely remove NODE_LABEL environment variable
-		nodeLabelRegex1 := regexp.MustCompile(`(?m)^\s*-\s+name:\s+NODE_LABEL\s+value:\s+.*$`)
-		nodeLabelRegex2 := regexp.MustCompile(`(?m)^\s*-\s+name:\s+["']?NODE_LABEL["']?\s+value:\s+.*$`)
-		nodeLabelRegex3 := regexp.MustCompile(`(?m)^\s*name:\s+["']?NODE_LABEL["']?\s+value:\s+.*$`)
-
-		manifestStr = nodeLabelRegex1.ReplaceAllString(manifestStr, "")
-		manifestStr = nodeLabelRegex2.ReplaceAllString(manifestStr, "")
-		manifestStr = nodeLabelRegex3.ReplaceAllString(manifestStr, "")
-
-		// Directly replace any target with nodeLabel
-		targetNodeLabelRegex1 := regexp.MustCompile(`target:\s*{nodeLabel:\s*[^}]*}`)
-		targetNodeLabelRegex2 := regexp.MustCompile(`"target":\s*"{nodeLabel:\s*[^}]*}"`)
-		targetNodeLabelRegex3 := regexp.MustCompile(`"target":\s*"?{nodeLabel:[^}]*}"?`)
-		targetNodeLabelRegex4 := regexp.MustCompile(`target:[^{]*{nodeLabel:[^}]*}`)
-
-		manifestStr = targetNodeLabelRegex1.ReplaceAllString(manifestStr, "target: {}")
-		manifestStr = targetNodeLabelRegex2.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-		manifestStr = targetNodeLabelRegex3.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-		manifestStr = targetNodeLabelRegex4.ReplaceAllString(manifestStr, "target: {}")
-
-		// Replace specific __NODE_LABEL_VALUE__ directly
-		manifestStr = strings.ReplaceAll(manifestStr, "__NODE_LABEL_VALUE__", "")
-		manifestStr = strings.ReplaceAll(manifestStr, "nodeLabel: ", "")
-		manifestStr = strings.ReplaceAll(manifestStr, "nodeLabel:", "")
-
-		// Replace experiment-specific network parameters
-		switch experimentType {
-		case PodNetworkCorruption:
-			manifestStr = strings.ReplaceAll(manifestStr, "__NETWORK_PACKET_CORRUPTION_PERCENTAGE_VALUE__", config.NetworkPacketCorruptionPercentage)
-		case PodNetworkLatency:
-			manifestStr = strings.ReplaceAll(manifestStr, "__NETWORK_LATENCY_VALUE__", config.NetworkLatency)
-			manifestStr = strings.ReplaceAll(manifestStr, "__JITTER_VALUE__", config.Jitter)
-		case PodNetworkLoss:
-			manifestStr = strings.ReplaceAll(manifestStr, "__NETWORK_PACKET_LOSS_PERCENTAGE_VALUE__", config.NetworkPacketLossPercentage)
-		case PodNetworkDuplication:
-			manifestStr = strings.ReplaceAll(manifestStr, "__NETWORK_PACKET_DUPLICATION_PERCENTAGE_VALUE__", config.NetworkPacketDuplicationPercentage)
+	// Steps of the main template: install, the fault steps and finally the cleanup
+	workflowSteps := [][]WorkflowStep{
+		{newWorkflowStep("install-chaos-faults")},
+	}
+	hub := getChaosHub()
+	images := getImageConfig()
+	var faultArtifacts []Artifact
+	var runTemplates []Template
+	installed := map[ExperimentType]bool{}
+	occurrences := map[ExperimentType]int{}
+
+	for _, step := range steps {
+		if len(step) == 0 {
+			return "", fmt.Errorf("empty fault step provided for experiment %s", experimentName)
 		}
-	} else {
-		// Replace non-network specific placeholders
-		switch experimentType {
-		case PodCPUHog:
-			manifestStr = strings.ReplaceAll(manifestStr, "__CPU_CORES_VALUE__", config.CPUCores)
-		case PodMemoryHog:
-			manifestStr = strings.ReplaceAll(manifestStr, "__MEMORY_CONSUMPTION_VALUE__", config.MemoryConsumption)
-		case PodAutoscaler:
-			manifestStr = strings.ReplaceAll(manifestStr, "__REPLICA_COUNT_VALUE__", config.ReplicaCount)
-		case ContainerKill:
-			manifestStr = strings.ReplaceAll(manifestStr, "__SIGNAL_VALUE__", config.Signal)
-			manifestStr = strings.ReplaceAll(manifestStr, "__SOCKET_PATH_VALUE__", config.SocketPath)
-			manifestStr = strings.ReplaceAll(manifestStr, "__CONTAINER_RUNTIME_VALUE__", config.ContainerRuntime)
-		case DiskFill:
-			manifestStr = strings.ReplaceAll(manifestStr, "__FILL_PERCENTAGE_VALUE__", config.FillPercentage)
-			manifestStr = strings.ReplaceAll(manifestStr, "__DATA_BLOCK_SIZE_VALUE__", config.DataBlockSize)
-			manifestStr = strings.ReplaceAll(manifestStr, "__EPHEMERAL_STORAGE_MEBIBYTES_VALUE__", config.EphemeralStorageMebibytes)
-
-			// Handle NODE_LABEL specially for DiskFill experiment - if it's empty, remove the entire env var entry
-			if config.NodeLabel == "" {
-				// More aggressive pattern match for disk-fill
-				nodeLabelRegex1 := regexp.MustCompile(`\s*-\s+name:\s+NODE_LABEL\s+value:\s+["']?__NODE_LABEL_VALUE__["']?\s*`)
-				nodeLabelRegex2 := regexp.MustCompile(`\s*-\s+name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-				nodeLabelRegex3 := regexp.MustCompile(`\s*name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-
-				manifestStr = nodeLabelRegex1.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex2.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex3.ReplaceAllString(manifestStr, "")
-
-				// For yaml target field with nodeLabel - matching more formats
-				targetNodeLabelRegex1 := regexp.MustCompile(`target:\s*{nodeLabel:\s*__NODE_LABEL_VALUE__}`)
-				targetNodeLabelRegex2 := regexp.MustCompile(`"target":\s*"{nodeLabel:\s*__NODE_LABEL_VALUE__}"`)
-				targetNodeLabelRegex3 := regexp.MustCompile(`target:\s*["{]\s*nodeLabel:\s*__NODE_LABEL_VALUE__\s*[}"]`)
-				targetNodeLabelRegex4 := regexp.MustCompile(`"target":\s*"?{nodeLabel:\s*__NODE_LABEL_VALUE__}"?`)
-
-				manifestStr = targetNodeLabelRegex1.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex2.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-				manifestStr = targetNodeLabelRegex3.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex4.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-			} else {
-				manifestStr = strings.ReplaceAll(manifestStr, "__NODE_LABEL_VALUE__", config.NodeLabel)
+		var parallelSteps []WorkflowStep
+		for _, fault := range step {
+			hubFault, err := hub.Get(string(fault.Type))
+			if err != nil {
+				return "", fmt.Errorf("unsupported experiment type %s: %v", fault.Type, err)
 			}
-		case NodeCPUHog:
-			// NODE_LABEL specific handling (similar to disk-fill)
-			if config.NodeLabel == "" {
-				// Remove NODE_LABEL environment variable if empty
-				nodeLabelRegex1 := regexp.MustCompile(`\s*-\s+name:\s+NODE_LABEL\s+value:\s+["']?__NODE_LABEL_VALUE__["']?\s*`)
-				nodeLabelRegex2 := regexp.MustCompile(`\s*-\s+name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-				nodeLabelRegex3 := regexp.MustCompile(`\s*name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-
-				manifestStr = nodeLabelRegex1.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex2.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex3.ReplaceAllString(manifestStr, "")
-
-				// For yaml target field with nodeLabel - matching more formats
-				targetNodeLabelRegex1 := regexp.MustCompile(`target:\s*{nodeLabel:\s*__NODE_LABEL_VALUE__}`)
-				targetNodeLabelRegex2 := regexp.MustCompile(`"target":\s*"{nodeLabel:\s*__NODE_LABEL_VALUE__}"`)
-				targetNodeLabelRegex3 := regexp.MustCompile(`target:\s*["{]\s*nodeLabel:\s*__NODE_LABEL_VALUE__\s*[}"]`)
-				targetNodeLabelRegex4 := regexp.MustCompile(`"target":\s*"?{nodeLabel:\s*__NODE_LABEL_VALUE__}"?`)
-
-				manifestStr = targetNodeLabelRegex1.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex2.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-				manifestStr = targetNodeLabelRegex3.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex4.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-			} else {
-				manifestStr = strings.ReplaceAll(manifestStr, "__NODE_LABEL_VALUE__", config.NodeLabel)
+
+			// The ChaosExperiment only needs to be installed once per fault type
+			if !installed[fault.Type] {
+				experimentData, err := getChaosExperiment(fault, hubFault, images)
+				if err != nil {
+					return "", err
+				}
+				installed[fault.Type] = true
+				faultArtifacts = append(faultArtifacts, Artifact{
+					Name: string(fault.Type) + "-ce5",
+					Path: "/tmp/" + string(fault.Type) + "-ce5.yaml",
+					Raw:  &RawArtifact{Data: experimentData},
+				})
 			}
-		case NodeMemoryHog:
-			// Replace memory consumption values
-			manifestStr = strings.ReplaceAll(manifestStr, "__MEMORY_CONSUMPTION_PERCENTAGE_VALUE__", config.MemoryConsumptionPercentage)
-			manifestStr = strings.ReplaceAll(manifestStr, "__MEMORY_CONSUMPTION_MEBIBYTES_VALUE__", config.MemoryConsumptionMebibytes)
-			manifestStr = strings.ReplaceAll(manifestStr, "__NUMBER_OF_WORKERS_VALUE__", config.NumberOfWorkers)
-			manifestStr = strings.ReplaceAll(manifestStr, "__TARGET_NODES_VALUE__", config.TargetPods)
-			manifestStr = strings.ReplaceAll(manifestStr, "__NODES_AFFECTED_PERC_VALUE__", config.PodsAffectedPerc)
-
-			// Handle NODE_LABEL specially - if it's empty, replace with empty string
-			if config.NodeLabel == "" {
-				// Remove NODE_LABEL environment variable if empty
-				nodeLabelRegex1 := regexp.MustCompile(`\s*-\s+name:\s+NODE_LABEL\s+value:\s+["']?__NODE_LABEL_VALUE__["']?\s*`)
-				nodeLabelRegex2 := regexp.MustCompile(`\s*-\s+name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-				nodeLabelRegex3 := regexp.MustCompile(`\s*name:\s+["']?NODE_LABEL["']?\s+value:\s+.*\s*`)
-
-				manifestStr = nodeLabelRegex1.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex2.ReplaceAllString(manifestStr, "")
-				manifestStr = nodeLabelRegex3.ReplaceAllString(manifestStr, "")
-
-				// For yaml target field with nodeLabel - matching more formats
-				targetNodeLabelRegex1 := regexp.MustCompile(`target:\s*{nodeLabel:\s*__NODE_LABEL_VALUE__}`)
-				targetNodeLabelRegex2 := regexp.MustCompile(`"target":\s*"{nodeLabel:\s*__NODE_LABEL_VALUE__}"`)
-				targetNodeLabelRegex3 := regexp.MustCompile(`target:\s*["{]\s*nodeLabel:\s*__NODE_LABEL_VALUE__\s*[}"]`)
-				targetNodeLabelRegex4 := regexp.MustCompile(`"target":\s*"?{nodeLabel:\s*__NODE_LABEL_VALUE__}"?`)
-
-				manifestStr = targetNodeLabelRegex1.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex2.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-				manifestStr = targetNodeLabelRegex3.ReplaceAllString(manifestStr, "target: {}")
-				manifestStr = targetNodeLabelRegex4.ReplaceAllString(manifestStr, "\"target\": \"{}\"")
-			} else {
-				manifestStr = strings.ReplaceAll(manifestStr, "__NODE_LABEL_VALUE__", config.NodeLabel)
+
+			// Template names must be unique when the same fault is used more than once
+			occurrences[fault.Type]++
+			templateName := string(fault.Type) + "-ce5"
+			if occurrences[fault.Type] > 1 {
+				templateName = fmt.Sprintf("%s-%d", templateName, occurrences[fault.Type])
+			}
+
+			runTemplate, err := getRunTemplate(templateName, fault, hubFault, experimentName, images)
+			if err != nil {
+				return "", err
 			}
+			parallelSteps = append(parallelSteps, newWorkflowStep(templateName))
+			runTemplates = append(runTemplates, runTemplate)
 		}
+		workflowSteps = append(workflowSteps, parallelSteps)
 	}
 
-	return manifestStr, nil
-}
-
-func getExperimentTemplates(experimentType ExperimentType, config ExperimentConfig) ([]map[string]interface{}, error) {
-	var templates []map[string]interface{}
-
-	// Artifact name and path matching the working YAML
-	installTemplate := map[string]interface{}{
-		"name": "install-chaos-faults",
-		"inputs": map[string]interface{}{
-			"artifacts": []map[string]interface{}{
-				{
-					"name": string(experimentType) + "-ce5",
-					"path": "/tmp/" + string(experimentType) + "-ce5.yaml",
-					"raw": map[string]interface{}{
-						"data": getChaosExperimentData(experimentType),
-					},
+	workflowSteps = append(workflowSteps, []WorkflowStep{newWorkflowStep("cleanup-chaos-resources")})
+
+	// Main workflow template followed by the install, fault and cleanup templates
+	templates := []Template{{Name: entrypoint, Steps: workflowSteps}}
+	templates = append(templates, getInstallTemplate(faultArtifacts, images))
+	templates = append(templates, runTemplates...)
+	templates = append(templates, getCleanupTemplate(images))
+
+	runAsUser := int64(1000)
+	runAsNonRoot := true
+	workflow := Workflow{
+		APIVersion: "argoproj.io/v1alpha1",
+		Kind:       "Workflow",
+		Metadata: WorkflowMetadata{
+			Name:      experimentName,
+			Namespace: "litmus",
+		},
+		Spec: WorkflowSpec{
+			Entrypoint:         entrypoint,
+			ServiceAccountName: "argo-chaos",
+			PodGC:              &PodGC{Strategy: "OnWorkflowCompletion"},
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:    &runAsUser,
+				RunAsNonRoot: &runAsNonRoot,
+			},
+			Arguments: Arguments{
+				Parameters: []Parameter{
+					{Name: "adminModeNamespace", Value: "litmus"},
 				},
 			},
+			Templates:        templates,
+			ImagePullSecrets: images.pullSecrets(),
 		},
-		"container": map[string]interface{}{
-			"name":    "",
-			"image":   "litmuschaos/k8s:2.11.0",
-			"command": []string{"sh", "-c"},
-			"args": []string{
+		Status: map[string]interface{}{},
+	}
+
+	// Convert to JSON and then to pretty-printed string
+	jsonBytes, err := json.MarshalIndent(workflow, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal workflow manifest: %v", err)
+	}
+
+	return string(jsonBytes), nil
+}
+
+// newWorkflowStep returns a step which runs the template of the same name
+func newWorkflowStep(templateName string) WorkflowStep {
+	return WorkflowStep{
+		Name:     templateName,
+		Template: templateName,
+	}
+}
+
+// getInstallTemplate returns the template which installs the ChaosExperiments of all the faults
+func getInstallTemplate(faultArtifacts []Artifact, images ImageConfig) Template {
+	return Template{
+		Name:   "install-chaos-faults",
+		Inputs: &Inputs{Artifacts: faultArtifacts},
+		Container: &corev1.Container{
+			Image:   images.Rewrite(k8sImage),
+			Command: []string{"sh", "-c"},
+			Args: []string{
 				"kubectl apply -f /tmp/ -n {{workflow.parameters.adminModeNamespace}} && sleep 30",
 			},
-			"resources": map[string]interface{}{},
 		},
 	}
+}
 
-	// Create the raw data string for the chaos engine
-	engineData := getChaosEngineData(experimentType)
-
-	// Create a raw string version of the probe annotation to directly insert into the YAML
-	probeAnnotation := fmt.Sprintf("probeRef: '[{\"name\":\"%s\",\"mode\":\"%s\"}]'",
-		config.ProbeName, config.ProbeMode)
-
-	// Add the annotation directly into the YAML string
-	engineData = strings.Replace(
-		engineData,
-		"metadata:",
-		"metadata:\n  annotations:\n    "+probeAnnotation,
-		1)
+// getRunTemplate returns the template which creates the ChaosEngine of a fault and waits for its completion
+func getRunTemplate(templateName string, fault Fault, hubFault *chaoshub.Fault, experimentName string, images ImageConfig) (Template, error) {
+	engine, err := getChaosEngine(fault, hubFault, experimentName, images)
+	if err != nil {
+		return Template{}, err
+	}
+	engineData, err := marshalEngine(engine, fault.Config.InlineProbes)
+	if err != nil {
+		return Template{}, fmt.Errorf("failed to build the chaos engine of %s: %v", fault.Type, err)
+	}
 
-	runTemplate := map[string]interface{}{
-		"name": string(experimentType) + "-ce5",
-		"inputs": map[string]interface{}{
-			"artifacts": []map[string]interface{}{
+	return Template{
+		Name: templateName,
+		Inputs: &Inputs{
+			Artifacts: []Artifact{
 				{
-					"name": string(experimentType) + "-ce5",
-					"path": "/tmp/" + string(experimentType) + "-ce5.yaml",
-					"raw": map[string]interface{}{
-						"data": engineData,
-					},
+					Name: templateName,
+					Path: "/tmp/" + templateName + ".yaml",
+					Raw:  &RawArtifact{Data: engineData},
 				},
 			},
 		},
-		"outputs": map[string]interface{}{},
-		"metadata": map[string]interface{}{
-			"labels": map[string]string{
+		Outputs: &Outputs{},
+		Metadata: &TemplateMetadata{
+			Labels: map[string]string{
 				"weight": "10",
 			},
 		},
-		"container": map[string]interface{}{
-			"name":  "",
-			"image": "docker.io/litmuschaos/litmus-checker:2.11.0",
-			"args": []string{
-				"-file=/tmp/" + string(experimentType) + "-ce5.yaml",
+		Container: &corev1.Container{
+			Image: images.Rewrite(litmusCheckerImage),
+			Args: []string{
+				"-file=/tmp/" + templateName + ".yaml",
 				"-saveName=/tmp/engine-name",
 			},
-			"resources": map[string]interface{}{},
 		},
-	}
+	}, nil
+}
 
-	cleanupTemplate := map[string]interface{}{
-		"name":     "cleanup-chaos-resources",
-		"inputs":   map[string]interface{}{},
-		"outputs":  map[string]interface{}{},
-		"metadata": map[string]interface{}{},
-		"container": map[string]interface{}{
-			"name":    "",
-			"image":   "litmuschaos/k8s:2.11.0",
-			"command": []string{"sh", "-c"},
-			"args": []string{
+// getCleanupTemplate returns the template which removes the ChaosEngines created by the workflow
+func getCleanupTemplate(images ImageConfig) Template {
+	return Template{
+		Name:     "cleanup-chaos-resources",
+		Inputs:   &Inputs{},
+		Outputs:  &Outputs{},
+		Metadata: &TemplateMetadata{},
+		Container: &corev1.Container{
+			Image:   images.Rewrite(k8sImage),
+			Command: []string{"sh", "-c"},
+			Args: []string{
 				"kubectl delete chaosengine -l workflow_run_id={{ workflow.uid }} -n {{workflow.parameters.adminModeNamespace}}",
 			},
-			"resources": map[string]interface{}{},
 		},
 	}
-
-	templates = append(templates, installTemplate, runTemplate, cleanupTemplate)
-	return templates, nil
-}
-
-// getChaosExperimentData returns the ChaosExperiment definition for the specified experiment type
-func getChaosExperimentData(experimentType ExperimentType) string {
-	switch experimentType {
-	case PodDelete:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Deletes a pod belonging to a deployment/statefulset/daemonset
-kind: ChaosExperiment
-metadata:
-  name: pod-delete
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-      # Additional permissions omitted for brevity
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name pod-delete
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: KILL_COUNT
-      value: ''
-    - name: FORCE
-      value: 'true'
-    - name: CHAOS_INTERVAL
-      value: '__CHAOS_INTERVAL_VALUE__'
-    labels:
-      name: pod-delete`
-	case PodCPUHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects cpu consumption on pods belonging to an app deployment
-kind: ChaosExperiment
-metadata:
-  name: pod-cpu-hog
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-          - "batch"
-          - "litmuschaos.io"
-        resources:
-          - "jobs"
-          - "pods"
-          - "pods/log"
-          - "events"
-          - "chaosengines"
-          - "chaosexperiments"
-          - "chaosresults"
-        verbs:
-          - "create"
-          - "list"
-          - "get"
-          - "patch"
-          - "update"
-          - "delete"
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    args:
-    - -c
-    - ./experiments -name pod-cpu-hog
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: CHAOS_INTERVAL
-      value: '__CHAOS_INTERVAL_VALUE__'
-    - name: CPU_CORES
-      value: '__CPU_CORES_VALUE__'
-    - name: PODS_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    labels:
-      name: pod-cpu-hog`
-
-	case PodMemoryHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects memory consumption on pods belonging to an app deployment
-kind: ChaosExperiment
-metadata:
-  name: pod-memory-hog
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-          - "batch"
-          - "litmuschaos.io"
-        resources:
-          - "jobs"
-          - "pods"
-          - "pods/log"
-          - "events"
-          - "chaosengines"
-          - "chaosexperiments"
-          - "chaosresults"
-        verbs:
-          - "create"
-          - "list"
-          - "get"
-          - "patch"
-          - "update"
-          - "delete"
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    args:
-    - -c
-    - ./experiments -name pod-memory-hog
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: CHAOS_INTERVAL
-      value: '__CHAOS_INTERVAL_VALUE__'
-    - name: MEMORY_CONSUMPTION
-      value: '__MEMORY_CONSUMPTION_VALUE__'
-    - name: PODS_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    labels:
-      name: pod-memory-hog`
-
-	case PodNetworkCorruption:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Inject network packet corruption into application pod
-kind: ChaosExperiment
-metadata:
-  name: pod-network-corruption
-  labels:
-    name: pod-network-corruption
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name pod-network-corruption
-    command:
-    - /bin/bash
-    env:
-    - name: TARGET_CONTAINER
-      value: "__TARGET_CONTAINER_VALUE__"
-    - name: LIB_IMAGE
-      value: "__LIB_IMAGE_VALUE__"
-    - name: NETWORK_INTERFACE
-      value: "__NETWORK_INTERFACE_VALUE__"
-    - name: TC_IMAGE
-      value: "__TC_IMAGE_VALUE__"
-    - name: NETWORK_PACKET_CORRUPTION_PERCENTAGE
-      value: "__NETWORK_PACKET_CORRUPTION_PERCENTAGE_VALUE__"
-    - name: TOTAL_CHAOS_DURATION
-      value: "__CHAOS_DURATION_VALUE__"
-    - name: RAMP_TIME
-      value: "__RAMP_TIME_VALUE__"
-    - name: PODS_AFFECTED_PERC
-      value: "__PODS_AFFECTED_PERC_VALUE__"
-    - name: TARGET_PODS
-      value: "__TARGET_PODS_VALUE__"
-    - name: NODE_LABEL
-      value: "__NODE_LABEL_VALUE__"
-    - name: CONTAINER_RUNTIME
-      value: "__CONTAINER_RUNTIME_VALUE__"
-    - name: DESTINATION_IPS
-      value: "__DESTINATION_IPS_VALUE__"
-    - name: DESTINATION_HOSTS
-      value: "__DESTINATION_HOSTS_VALUE__"
-    - name: SOCKET_PATH
-      value: "__SOCKET_PATH_VALUE__"
-    - name: DEFAULT_HEALTH_CHECK
-      value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-    - name: SEQUENCE
-      value: "__SEQUENCE_VALUE__"
-    labels:
-      name: pod-network-corruption`
-
-	case PodNetworkLatency:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects network latency on pods belonging to an app deployment
-kind: ChaosExperiment
-metadata:
-  name: pod-network-latency
-  labels:
-    name: pod-network-latency
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name pod-network-latency
-    command:
-    - /bin/bash
-    env:
-    - name: TARGET_CONTAINER
-      value: "__TARGET_CONTAINER_VALUE__"
-    - name: NETWORK_INTERFACE
-      value: "__NETWORK_INTERFACE_VALUE__"
-    - name: LIB_IMAGE
-      value: "__LIB_IMAGE_VALUE__"
-    - name: TC_IMAGE
-      value: "__TC_IMAGE_VALUE__"
-    - name: NETWORK_LATENCY
-      value: "__NETWORK_LATENCY_VALUE__"
-    - name: TOTAL_CHAOS_DURATION
-      value: "__CHAOS_DURATION_VALUE__"
-    - name: RAMP_TIME
-      value: "__RAMP_TIME_VALUE__"
-    - name: JITTER
-      value: "__JITTER_VALUE__"
-    - name: PODS_AFFECTED_PERC
-      value: "__PODS_AFFECTED_PERC_VALUE__"
-    - name: TARGET_PODS
-      value: "__TARGET_PODS_VALUE__"
-    - name: CONTAINER_RUNTIME
-      value: "__CONTAINER_RUNTIME_VALUE__"
-    - name: DEFAULT_HEALTH_CHECK
-      value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-    - name: DESTINATION_IPS
-      value: "__DESTINATION_IPS_VALUE__"
-    - name: DESTINATION_HOSTS
-      value: "__DESTINATION_HOSTS_VALUE__"
-    - name: SOCKET_PATH
-      value: "__SOCKET_PATH_VALUE__"
-    - name: NODE_LABEL
-      value: "__NODE_LABEL_VALUE__"
-    - name: SEQUENCE
-      value: "__SEQUENCE_VALUE__"
-    labels:
-      name: pod-network-latency`
-
-	case PodNetworkLoss:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects network packet loss on pods belonging to an app deployment
-kind: ChaosExperiment
-metadata:
-  name: pod-network-loss
-  labels:
-    name: pod-network-loss
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name pod-network-loss
-    command:
-    - /bin/bash
-    env:
-    - name: TARGET_CONTAINER
-      value: "__TARGET_CONTAINER_VALUE__"
-    - name: LIB_IMAGE
-      value: "__LIB_IMAGE_VALUE__"
-    - name: NETWORK_INTERFACE
-      value: "__NETWORK_INTERFACE_VALUE__"
-    - name: TC_IMAGE
-      value: "__TC_IMAGE_VALUE__"
-    - name: NETWORK_PACKET_LOSS_PERCENTAGE
-      value: "__NETWORK_PACKET_LOSS_PERCENTAGE_VALUE__"
-    - name: TOTAL_CHAOS_DURATION
-      value: "__CHAOS_DURATION_VALUE__"
-    - name: RAMP_TIME
-      value: "__RAMP_TIME_VALUE__"
-    - name: PODS_AFFECTED_PERC
-      value: "__PODS_AFFECTED_PERC_VALUE__"
-    - name: DEFAULT_HEALTH_CHECK
-      value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-    - name: TARGET_PODS
-      value: "__TARGET_PODS_VALUE__"
-    - name: NODE_LABEL
-      value: "__NODE_LABEL_VALUE__"
-    - name: CONTAINER_RUNTIME
-      value: "__CONTAINER_RUNTIME_VALUE__"
-    - name: DESTINATION_IPS
-      value: "__DESTINATION_IPS_VALUE__"
-    - name: DESTINATION_HOSTS
-      value: "__DESTINATION_HOSTS_VALUE__"
-    - name: SOCKET_PATH
-      value: "__SOCKET_PATH_VALUE__"
-    - name: SEQUENCE
-      value: "__SEQUENCE_VALUE__"
-    labels:
-      name: pod-network-loss`
-
-	case PodNetworkDuplication:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects network packet duplication on pods belonging to an app deployment
-kind: ChaosExperiment
-metadata:
-  name: pod-network-duplication
-  labels:
-    name: pod-network-duplication
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name pod-network-duplication
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: "__CHAOS_DURATION_VALUE__"
-    - name: RAMP_TIME
-      value: "__RAMP_TIME_VALUE__"
-    - name: TARGET_CONTAINER
-      value: "__TARGET_CONTAINER_VALUE__"
-    - name: TC_IMAGE
-      value: "__TC_IMAGE_VALUE__"
-    - name: NETWORK_INTERFACE
-      value: "__NETWORK_INTERFACE_VALUE__"
-    - name: NETWORK_PACKET_DUPLICATION_PERCENTAGE
-      value: "__NETWORK_PACKET_DUPLICATION_PERCENTAGE_VALUE__"
-    - name: TARGET_PODS
-      value: "__TARGET_PODS_VALUE__"
-    - name: NODE_LABEL
-      value: "__NODE_LABEL_VALUE__"
-    - name: PODS_AFFECTED_PERC
-      value: "__PODS_AFFECTED_PERC_VALUE__"
-    - name: LIB_IMAGE
-      value: "__LIB_IMAGE_VALUE__"
-    - name: CONTAINER_RUNTIME
-      value: "__CONTAINER_RUNTIME_VALUE__"
-    - name: DEFAULT_HEALTH_CHECK
-      value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-    - name: DESTINATION_IPS
-      value: "__DESTINATION_IPS_VALUE__"
-    - name: DESTINATION_HOSTS
-      value: "__DESTINATION_HOSTS_VALUE__"
-    - name: SOCKET_PATH
-      value: "__SOCKET_PATH_VALUE__"
-    - name: SEQUENCE
-      value: "__SEQUENCE_VALUE__"
-    labels:
-      name: pod-network-duplication`
-
-	case PodAutoscaler:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Scale the application replicas and test the node autoscaling on cluster
-kind: ChaosExperiment
-metadata:
-  name: pod-autoscaler
-  labels:
-    name: pod-autoscaler
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Cluster
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-      - apiGroups:
-          - ""
-        resources:
-          - events
-        verbs:
-          - create
-          - get
-          - list
-          - patch
-          - update
-      - apiGroups:
-          - ""
-        resources:
-          - configmaps
-        verbs:
-          - get
-          - list
-      - apiGroups:
-          - ""
-        resources:
-          - pods/log
-        verbs:
-          - get
-          - list
-          - watch
-      - apiGroups:
-          - ""
-        resources:
-          - pods/exec
-        verbs:
-          - get
-          - list
-          - create
-      - apiGroups:
-          - apps
-        resources:
-          - deployments
-          - statefulsets
-        verbs:
-          - list
-          - get
-          - patch
-          - update
-      - apiGroups:
-          - batch
-        resources:
-          - jobs
-        verbs:
-          - create
-          - list
-          - get
-          - delete
-          - deletecollection
-      - apiGroups:
-          - litmuschaos.io
-        resources:
-          - chaosengines
-          - chaosexperiments
-          - chaosresults
-        verbs:
-          - create
-          - list
-          - get
-          - patch
-          - update
-          - delete
-    image: litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0
-    imagePullPolicy: Always
-    args:
-      - -c
-      - ./experiments -name pod-autoscaler
-    command:
-      - /bin/bash
-    env:
-      - name: TOTAL_CHAOS_DURATION
-        value: "__CHAOS_DURATION_VALUE__"
-      - name: RAMP_TIME
-        value: "__RAMP_TIME_VALUE__"
-      - name: REPLICA_COUNT
-        value: "__REPLICA_COUNT_VALUE__"
-      - name: DEFAULT_HEALTH_CHECK
-        value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-    labels:
-      name: pod-autoscaler
-      app.kubernetes.io/part-of: litmus
-      app.kubernetes.io/component: experiment-job
-      app.kubernetes.io/version: 3.16.0`
-
-	case ContainerKill:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Kills a container belonging to an application pod 
-kind: ChaosExperiment
-metadata:
-  name: container-kill
-  labels:
-    name: container-kill
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name container-kill
-    command:
-    - /bin/bash
-    env:
-    - name: TARGET_CONTAINER
-      value: '__TARGET_CONTAINER_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: TARGET_PODS
-      value: '__TARGET_PODS_VALUE__'
-    - name: CHAOS_INTERVAL
-      value: '__CHAOS_INTERVAL_VALUE__'
-    - name: SIGNAL
-      value: '__SIGNAL_VALUE__'
-    - name: SOCKET_PATH
-      value: '__SOCKET_PATH_VALUE__'
-    - name: CONTAINER_RUNTIME
-      value: '__CONTAINER_RUNTIME_VALUE__'
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: PODS_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: DEFAULT_HEALTH_CHECK
-      value: '__DEFAULT_HEALTH_CHECK_VALUE__'
-    - name: LIB_IMAGE
-      value: 'litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0'
-    - name: SEQUENCE
-      value: 'parallel'
-    labels:
-      name: container-kill
-    configMaps:
-    - name: container-kill
-      mountPath: /tmp/
-    secrets:
-    - name: container-kill
-      mountPath: /tmp/
-    hostFileVolumes:
-    - name: socket-path
-      mountPath: __SOCKET_PATH_VALUE__
-      nodePath: __SOCKET_PATH_VALUE__
-    securityContext:
-      privileged: true
-      capabilities:
-        add:
-        - SYS_ADMIN
-      hostPID: true`
-
-	case DiskFill:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Fillup Ephemeral Storage of a Resource
-kind: ChaosExperiment
-metadata:
-  name: disk-fill
-  labels:
-    name: disk-fill
-    app.kubernetes.io/part-of: litmus
-    app.kubernetes.io/component: chaosexperiment
-    app.kubernetes.io/version: 3.16.0
-spec:
-  definition:
-    scope: Namespaced
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name disk-fill
-    command:
-    - /bin/bash
-    env:
-    - name: TARGET_CONTAINER
-      value: '__TARGET_CONTAINER_VALUE__'
-    - name: FILL_PERCENTAGE
-      value: '__FILL_PERCENTAGE_VALUE__'
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: DATA_BLOCK_SIZE
-      value: '__DATA_BLOCK_SIZE_VALUE__'
-    - name: TARGET_PODS
-      value: '__TARGET_PODS_VALUE__'
-    - name: EPHEMERAL_STORAGE_MEBIBYTES
-      value: '__EPHEMERAL_STORAGE_MEBIBYTES_VALUE__'
-    - name: PODS_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: DEFAULT_HEALTH_CHECK
-      value: '__DEFAULT_HEALTH_CHECK_VALUE__'
-    - name: LIB_IMAGE
-      value: 'litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0'
-    - name: SOCKET_PATH
-      value: '/run/containerd/containerd.sock'
-    - name: CONTAINER_RUNTIME
-      value: 'containerd'
-    - name: SEQUENCE
-      value: 'parallel'
-    labels:
-      name: disk-fill`
-
-	case NodeCPUHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects cpu consumption on node
-kind: ChaosExperiment
-metadata:
-  name: node-cpu-hog
-  labels:
-    name: node-cpu-hog
-spec:
-  definition:
-    scope: Cluster
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name node-cpu-hog
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: NODE_CPU_CORE
-      value: ''
-    - name: CPU_LOAD
-      value: '100'
-    - name: NODES_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: TARGET_NODES
-      value: '__TARGET_PODS_VALUE__'
-    - name: DEFAULT_HEALTH_CHECK
-      value: '__DEFAULT_HEALTH_CHECK_VALUE__'
-    - name: LIB_IMAGE
-      value: 'litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0'
-    - name: SEQUENCE
-      value: 'parallel'
-    labels:
-      name: node-cpu-hog`
-
-	case NodeMemoryHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Give a memory hog on a node belonging to a deployment
-kind: ChaosExperiment
-metadata:
-  name: node-memory-hog
-  labels:
-    name: node-memory-hog
-spec:
-  definition:
-    scope: Cluster
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-      - apiGroups:
-          - ""
-        resources:
-          - events
-        verbs:
-          - create
-          - get
-          - list
-          - patch
-          - update
-      - apiGroups:
-          - ""
-        resources:
-          - configmaps
-        verbs:
-          - get
-          - list
-      - apiGroups:
-          - ""
-        resources:
-          - pods/log
-        verbs:
-          - get
-          - list
-          - watch
-      - apiGroups:
-          - ""
-        resources:
-          - pods/exec
-        verbs:
-          - get
-          - list
-          - create
-      - apiGroups:
-          - batch
-        resources:
-          - jobs
-        verbs:
-          - create
-          - list
-          - get
-          - delete
-          - deletecollection
-      - apiGroups:
-          - litmuschaos.io
-        resources:
-          - chaosengines
-          - chaosexperiments
-          - chaosresults
-        verbs:
-          - create
-          - list
-          - get
-          - patch
-          - update
-          - delete
-      - apiGroups:
-          - ""
-        resources:
-          - nodes
-        verbs:
-          - get
-          - list
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name node-memory-hog
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: MEMORY_CONSUMPTION_PERCENTAGE
-      value: '__MEMORY_CONSUMPTION_PERCENTAGE_VALUE__'
-    - name: MEMORY_CONSUMPTION_MEBIBYTES
-      value: '__MEMORY_CONSUMPTION_MEBIBYTES_VALUE__'
-    - name: NUMBER_OF_WORKERS
-      value: '__NUMBER_OF_WORKERS_VALUE__'
-    - name: TARGET_NODES
-      value: '__TARGET_NODES_VALUE__'
-    - name: NODES_AFFECTED_PERC
-      value: '__NODES_AFFECTED_PERC_VALUE__'
-    - name: DEFAULT_HEALTH_CHECK
-      value: '__DEFAULT_HEALTH_CHECK_VALUE__'
-    - name: LIB_IMAGE
-      value: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    - name: SEQUENCE
-      value: "parallel"
-    labels:
-      name: node-memory-hog`
-
-	case NodeIOStress:
-		return `apiVersion: litmuschaos.io/v1alpha1
-description:
-  message: |
-    Injects IO stress on node
-kind: ChaosExperiment
-metadata:
-  name: node-io-stress
-  labels:
-    name: node-io-stress
-spec:
-  definition:
-    scope: Cluster
-    permissions:
-      - apiGroups:
-          - ""
-        resources:
-          - pods
-        verbs:
-          - create
-          - delete
-          - get
-          - list
-          - patch
-          - update
-          - deletecollection
-    image: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-    imagePullPolicy: Always
-    args:
-    - -c
-    - ./experiments -name node-io-stress
-    command:
-    - /bin/bash
-    env:
-    - name: TOTAL_CHAOS_DURATION
-      value: '__CHAOS_DURATION_VALUE__'
-    - name: RAMP_TIME
-      value: '__RAMP_TIME_VALUE__'
-    - name: FILESYSTEM_UTILIZATION_PERCENTAGE
-      value: '10'
-    - name: FILESYSTEM_UTILIZATION_BYTES
-      value: ''
-    - name: NODES_AFFECTED_PERC
-      value: '__PODS_AFFECTED_PERC_VALUE__'
-    - name: TARGET_NODES
-      value: '__TARGET_PODS_VALUE__'
-    - name: DEFAULT_HEALTH_CHECK
-      value: '__DEFAULT_HEALTH_CHECK_VALUE__'
-    - name: LIB_IMAGE
-      value: 'litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0'
-    - name: SEQUENCE
-      value: 'parallel'
-    labels:
-      name: node-io-stress`
-
-	default:
-		return ""
-	}
-}
-
-// getChaosEngineData returns the ChaosEngine definition for the specified experiment type
-func getChaosEngineData(experimentType ExperimentType) string {
-	switch experimentType {
-	case PodDelete:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-delete-ce5
-spec:
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  engineState: active
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-delete
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: FORCE
-              value: "true"
-            - name: CHAOS_INTERVAL
-              value: "__CHAOS_INTERVAL_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"`
-
-	case PodCPUHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-cpu-hog-ce5
-spec:
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  engineState: active
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-cpu-hog
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: CPU_CORES
-              value: "__CPU_CORES_VALUE__"
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"`
-
-	case PodMemoryHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-memory-hog-ce5
-spec:
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  engineState: active
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-memory-hog
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: MEMORY_CONSUMPTION
-              value: "__MEMORY_CONSUMPTION_VALUE__"
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"`
-
-	case PodNetworkCorruption:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-network-corruption-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-network-corruption
-      spec:
-        components:
-          env:
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: LIB_IMAGE
-              value: "__LIB_IMAGE_VALUE__"
-            - name: NETWORK_INTERFACE
-              value: "__NETWORK_INTERFACE_VALUE__"
-            - name: TC_IMAGE
-              value: "__TC_IMAGE_VALUE__"
-            - name: NETWORK_PACKET_CORRUPTION_PERCENTAGE
-              value: "__NETWORK_PACKET_CORRUPTION_PERCENTAGE_VALUE__"
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: NODE_LABEL
-              value: "__NODE_LABEL_VALUE__"
-            - name: CONTAINER_RUNTIME
-              value: "__CONTAINER_RUNTIME_VALUE__"
-            - name: DESTINATION_IPS
-              value: "__DESTINATION_IPS_VALUE__"
-            - name: DESTINATION_HOSTS
-              value: "__DESTINATION_HOSTS_VALUE__"
-            - name: SOCKET_PATH
-              value: "__SOCKET_PATH_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: SEQUENCE
-              value: "__SEQUENCE_VALUE__"`
-
-	case PodNetworkLatency:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-network-latency-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-network-latency
-      spec:
-        components:
-          env:
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: NETWORK_INTERFACE
-              value: "__NETWORK_INTERFACE_VALUE__"
-            - name: LIB_IMAGE
-              value: "__LIB_IMAGE_VALUE__"
-            - name: TC_IMAGE
-              value: "__TC_IMAGE_VALUE__"
-            - name: NETWORK_LATENCY
-              value: "__NETWORK_LATENCY_VALUE__"
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: JITTER
-              value: "__JITTER_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: CONTAINER_RUNTIME
-              value: "__CONTAINER_RUNTIME_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: DESTINATION_IPS
-              value: "__DESTINATION_IPS_VALUE__"
-            - name: DESTINATION_HOSTS
-              value: "__DESTINATION_HOSTS_VALUE__"
-            - name: SOCKET_PATH
-              value: "__SOCKET_PATH_VALUE__"
-            - name: NODE_LABEL
-              value: "__NODE_LABEL_VALUE__"
-            - name: SEQUENCE
-              value: "__SEQUENCE_VALUE__"`
-
-	case PodNetworkLoss:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-network-loss-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-network-loss
-      spec:
-        components:
-          env:
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: LIB_IMAGE
-              value: "__LIB_IMAGE_VALUE__"
-            - name: NETWORK_INTERFACE
-              value: "__NETWORK_INTERFACE_VALUE__"
-            - name: TC_IMAGE
-              value: "__TC_IMAGE_VALUE__"
-            - name: NETWORK_PACKET_LOSS_PERCENTAGE
-              value: "__NETWORK_PACKET_LOSS_PERCENTAGE_VALUE__"
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: NODE_LABEL
-              value: "__NODE_LABEL_VALUE__"
-            - name: CONTAINER_RUNTIME
-              value: "__CONTAINER_RUNTIME_VALUE__"
-            - name: DESTINATION_IPS
-              value: "__DESTINATION_IPS_VALUE__"
-            - name: DESTINATION_HOSTS
-              value: "__DESTINATION_HOSTS_VALUE__"
-            - name: SOCKET_PATH
-              value: "__SOCKET_PATH_VALUE__"
-            - name: SEQUENCE
-              value: "__SEQUENCE_VALUE__"`
-
-	case PodNetworkDuplication:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-network-duplication-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-network-duplication
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: TC_IMAGE
-              value: "__TC_IMAGE_VALUE__"
-            - name: NETWORK_INTERFACE
-              value: "__NETWORK_INTERFACE_VALUE__"
-            - name: NETWORK_PACKET_DUPLICATION_PERCENTAGE
-              value: "__NETWORK_PACKET_DUPLICATION_PERCENTAGE_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: NODE_LABEL
-              value: "__NODE_LABEL_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: LIB_IMAGE
-              value: "__LIB_IMAGE_VALUE__"
-            - name: CONTAINER_RUNTIME
-              value: "__CONTAINER_RUNTIME_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: DESTINATION_IPS
-              value: "__DESTINATION_IPS_VALUE__"
-            - name: DESTINATION_HOSTS
-              value: "__DESTINATION_HOSTS_VALUE__"
-            - name: SOCKET_PATH
-              value: "__SOCKET_PATH_VALUE__"
-            - name: SEQUENCE
-              value: "__SEQUENCE_VALUE__"`
-
-	case PodAutoscaler:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  name: nginx-chaos
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: pod-autoscaler-ce5
-spec:
-  engineState: active
-  auxiliaryAppInfo: ""
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: pod-autoscaler
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: REPLICA_COUNT
-              value: "__REPLICA_COUNT_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"`
-
-	case ContainerKill:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: container-kill-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: container-kill
-      spec:
-        components:
-          env:
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: CHAOS_INTERVAL
-              value: "__CHAOS_INTERVAL_VALUE__"
-            - name: SIGNAL
-              value: "__SIGNAL_VALUE__"
-            - name: SOCKET_PATH
-              value: "__SOCKET_PATH_VALUE__"
-            - name: CONTAINER_RUNTIME
-              value: "__CONTAINER_RUNTIME_VALUE__"
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: LIB_IMAGE
-              value: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-            - name: SEQUENCE
-              value: "parallel"`
-
-	case DiskFill:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: disk-fill-ce5
-spec:
-  engineState: active
-  appinfo:
-    appns: __APP_NAMESPACE__
-    applabel: __APP_LABEL__
-    appkind: __APP_KIND__
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: disk-fill
-      spec:
-        components:
-          env:
-            - name: TARGET_CONTAINER
-              value: "__TARGET_CONTAINER_VALUE__"
-            - name: FILL_PERCENTAGE
-              value: "__FILL_PERCENTAGE_VALUE__"
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: DATA_BLOCK_SIZE
-              value: "__DATA_BLOCK_SIZE_VALUE__"
-            - name: TARGET_PODS
-              value: "__TARGET_PODS_VALUE__"
-            - name: EPHEMERAL_STORAGE_MEBIBYTES
-              value: "__EPHEMERAL_STORAGE_MEBIBYTES_VALUE__"
-            - name: PODS_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: LIB_IMAGE
-              value: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-            - name: SOCKET_PATH
-              value: "/run/containerd/containerd.sock"
-            - name: CONTAINER_RUNTIME
-              value: "containerd"
-            - name: SEQUENCE
-              value: "parallel"`
-
-	case NodeCPUHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: node-cpu-hog-ce5
-spec:
-  engineState: active
-  annotationCheck: "false"
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: node-cpu-hog
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: NODE_CPU_CORE
-              value: ""
-            - name: CPU_LOAD
-              value: "100"
-            - name: NODES_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: TARGET_NODES
-              value: "__TARGET_PODS_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: SEQUENCE
-              value: "parallel"`
-
-	case NodeMemoryHog:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: node-memory-hog-ce5
-spec:
-  engineState: active
-  annotationCheck: "false"
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: node-memory-hog
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: MEMORY_CONSUMPTION_PERCENTAGE
-              value: "__MEMORY_CONSUMPTION_PERCENTAGE_VALUE__"
-            - name: MEMORY_CONSUMPTION_MEBIBYTES
-              value: "__MEMORY_CONSUMPTION_MEBIBYTES_VALUE__"
-            - name: NUMBER_OF_WORKERS
-              value: "__NUMBER_OF_WORKERS_VALUE__"
-            - name: TARGET_NODES
-              value: "__TARGET_NODES_VALUE__"
-            - name: NODES_AFFECTED_PERC
-              value: "__NODES_AFFECTED_PERC_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: LIB_IMAGE
-              value: "litmuschaos.docker.scarf.sh/litmuschaos/go-runner:3.16.0"
-            - name: SEQUENCE
-              value: "parallel"`
-
-	case NodeIOStress:
-		return `apiVersion: litmuschaos.io/v1alpha1
-kind: ChaosEngine
-metadata:
-  namespace: "{{workflow.parameters.adminModeNamespace}}"
-  labels:
-    workflow_run_id: "{{ workflow.uid }}"
-    workflow_name: __EXPERIMENT_NAME__
-  generateName: node-io-stress-ce5
-spec:
-  engineState: active
-  annotationCheck: "false"
-  chaosServiceAccount: litmus-admin
-  experiments:
-    - name: node-io-stress
-      spec:
-        components:
-          env:
-            - name: TOTAL_CHAOS_DURATION
-              value: "__CHAOS_DURATION_VALUE__"
-            - name: RAMP_TIME
-              value: "__RAMP_TIME_VALUE__"
-            - name: FILESYSTEM_UTILIZATION_PERCENTAGE
-              value: "10"
-            - name: FILESYSTEM_UTILIZATION_BYTES
-              value: ""
-            - name: NODES_AFFECTED_PERC
-              value: "__PODS_AFFECTED_PERC_VALUE__"
-            - name: TARGET_NODES
-              value: "__TARGET_PODS_VALUE__"
-            - name: DEFAULT_HEALTH_CHECK
-              value: "__DEFAULT_HEALTH_CHECK_VALUE__"
-            - name: SEQUENCE
-              value: "parallel"`
-
-	default:
-		return ""
-	}
 }
 
 // Helper functions for constructing experiment requests with default configuration
 func ConstructPodDeleteExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodDelete)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodDelete, config)
 }
 
 func ConstructPodCPUHogExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodCPUHog)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodCPUHog, config)
 }
 
 func ConstructPodMemoryHogExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodMemoryHog)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodMemoryHog, config)
 }
 
+func ConstructPodIOStressExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodIOStress)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodIOStress, config)
+}
+
 func ConstructPodNetworkCorruptionExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodNetworkCorruption)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkCorruption, config)
 }
 
 func ConstructPodNetworkLatencyExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodNetworkLatency)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkLatency, config)
 }
 
 func ConstructPodNetworkLossExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodNetworkLoss)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkLoss, config)
 }
 
 func ConstructPodNetworkDuplicationExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodNetworkDuplication)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkDuplication, config)
 }
 
+func ConstructPodNetworkPartitionExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodNetworkPartition)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkPartition, config)
+}
+
+func ConstructPodNetworkRateLimitExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodNetworkRateLimit)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodNetworkRateLimit, config)
+}
+
+func ConstructPodHTTPLatencyExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodHTTPLatency)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodHTTPLatency, config)
+}
+
+func ConstructPodHTTPStatusCodeExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodHTTPStatusCode)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodHTTPStatusCode, config)
+}
+
+func ConstructPodHTTPModifyHeaderExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodHTTPModifyHeader)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodHTTPModifyHeader, config)
+}
+
+func ConstructPodHTTPModifyBodyExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodHTTPModifyBody)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodHTTPModifyBody, config)
+}
+
+func ConstructPodHTTPResetPeerExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodHTTPResetPeer)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodHTTPResetPeer, config)
+}
+
+func ConstructPodDNSErrorExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodDNSError)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodDNSError, config)
+}
+
+func ConstructPodDNSSpoofExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(PodDNSSpoof)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, PodDNSSpoof, config)
+}
+
 func ConstructPodAutoscalerExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(PodAutoscaler)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, PodAutoscaler, config)
 }
 
 func ConstructContainerKillExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(ContainerKill)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, ContainerKill, config)
 }
 
 func ConstructDiskFillExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(DiskFill)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, DiskFill, config)
 }
 
 func ConstructNodeCPUHogExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(NodeCPUHog)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, NodeCPUHog, config)
 }
 
 func ConstructNodeMemoryHogExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(NodeMemoryHog)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, NodeMemoryHog, config)
 }
 
 func ConstructNodeIOStressExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
 	config := GetDefaultExperimentConfig(NodeIOStress)
-	applyProbeConfigFromEnv(&config)
+	ApplyProbeConfigFromEnv(&config)
 	return ConstructExperimentRequest(details, experimentID, experimentName, NodeIOStress, config)
 }
 
-// applyProbeConfigFromEnv reads probe configuration from environment variables and applies them to the config
-func applyProbeConfigFromEnv(config *ExperimentConfig) {
+func ConstructNodeDrainExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(NodeDrain)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, NodeDrain, config)
+}
+
+func ConstructNodeTaintExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(NodeTaint)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, NodeTaint, config)
+}
+
+func ConstructKubeletServiceKillExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(KubeletServiceKill)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, KubeletServiceKill, config)
+}
+
+func ConstructNodeRestartExperimentRequest(details *types.ExperimentDetails, experimentID string, experimentName string) (*models.SaveChaosExperimentRequest, error) {
+	config := GetDefaultExperimentConfig(NodeRestart)
+	ApplyProbeConfigFromEnv(&config)
+	return ConstructExperimentRequest(details, experimentID, experimentName, NodeRestart, config)
+}
+
+// ApplyProbeConfigFromEnv reads probe configuration from environment variables and applies them to the config
+func ApplyProbeConfigFromEnv(config *ExperimentConfig) {
+	// Probes, as a comma separated list of name:mode pairs, they replace the single probe
+	if probes := os.Getenv("LITMUS_PROBES"); probes != "" {
+		config.Probes = parseProbeRefs(probes)
+		config.ProbeName = ""
+		config.ProbeMode = ""
+		log.Printf("Attaching the probes: %s\n", probes)
+	}
+
 	// Check if probe configuration is specified in environment variables
 	useExistingProbeStr := os.Getenv("LITMUS_USE_EXISTING_PROBE")
 	if useExistingProbeStr != "" {
@@ -2203,14 +945,14 @@ func applyProbeConfigFromEnv(config *ExperimentConfig) {
 		if err == nil {
 			config.UseExistingProbe = useExistingProbe
 
-			// Get probe name and mode regardless of useExistingProbe value
+			// Get probe name and mode regardless of useExistingProbe value, unless LITMUS_PROBES replaced them
 			probeName := os.Getenv("LITMUS_PROBE_NAME")
-			if probeName != "" {
+			if probeName != "" && len(config.Probes) == 0 {
 				config.ProbeName = probeName
 			}
 
 			probeMode := os.Getenv("LITMUS_PROBE_MODE")
-			if probeMode != "" {
+			if probeMode != "" && len(config.Probes) == 0 {
 				config.ProbeMode = probeMode
 			}
 
@@ -2226,11 +968,24 @@ func applyProbeConfigFromEnv(config *ExperimentConfig) {
 		} else {
 			log.Printf("Warning: Failed to parse LITMUS_USE_EXISTING_PROBE environment variable: %v\n", err)
 		}
-	} else {
+	} else if len(config.Probes) == 0 {
 		log.Printf("No probe configuration provided. Using default probe: %s with mode: %s\n", config.ProbeName, config.ProbeMode)
 	}
 }
 
+// parseProbeRefs parses a comma separated list of name:mode pairs, the mode is SOT if it is left out
+func parseProbeRefs(probes string) []ProbeRef {
+	var probeRefs []ProbeRef
+	for _, item := range environment.SplitList(probes) {
+		probeRef := ProbeRef{Name: item}
+		if i := strings.LastIndex(item, ":"); i != -1 {
+			probeRef = ProbeRef{Name: strings.TrimSpace(item[:i]), Mode: strings.TrimSpace(item[i+1:])}
+		}
+		probeRefs = append(probeRefs, probeRef)
+	}
+	return probeRefs
+}
+
 // CreateProbe creates a probe using the experiment details
 func CreateProbe(details *types.ExperimentDetails, sdkClient sdk.Client, litmusProjectID string) error {
 	if !details.CreateProbe {
@@ -2238,62 +993,41 @@ func CreateProbe(details *types.ExperimentDetails, sdkClient sdk.Client, litmusP
 		return nil
 	}
 
-	log.Printf("Creating a new probe with name: %s", details.ProbeName)
-
-	// Setup defaults for HTTP probe
-	trueBool := true
-	desc := fmt.Sprintf("HTTP probe for %s", details.ProbeName)
-
-	// Prepare probe request based on probe type
-	var probeReq probe.ProbeRequest
-
-	switch details.ProbeType {
-	case "httpProbe":
-		probeReq = probe.ProbeRequest{
-			Name:               details.ProbeName,
-			Description:        &desc,
-			Type:               probe.ProbeTypeHTTPProbe,
-			InfrastructureType: probe.InfrastructureTypeKubernetes,
-			Tags:               []string{"http", "probe", "chaos"},
-			KubernetesHTTPProperties: &probe.KubernetesHTTPProbeRequest{
-				ProbeTimeout: details.ProbeTimeout,
-				Interval:     details.ProbeInterval,
-				Attempt:      &details.ProbeAttempts,
-				URL:          details.ProbeURL,
-				Method: &probe.Method{
-					Get: &probe.GetMethod{
-						ResponseCode: details.ProbeResponseCode,
-						Criteria:     "==",
-					},
-				},
-				InsecureSkipVerify: &trueBool,
-			},
-		}
-	case "cmdProbe":
-		probeReq = probe.ProbeRequest{
-			Name:               details.ProbeName,
-			Description:        &desc,
-			Type:               probe.ProbeTypeCMDProbe,
-			InfrastructureType: probe.InfrastructureTypeKubernetes,
-			Tags:               []string{"cmd", "probe", "chaos"},
-			KubernetesCMDProperties: &probe.KubernetesCMDProbeRequest{
-				Command:      "ls -l",
-				ProbeTimeout: details.ProbeTimeout,
-				Interval:     details.ProbeInterval,
-				Attempt:      &details.ProbeAttempts,
-				Comparator: &probe.ComparatorInput{
-					Type:     "string",
-					Criteria: "contains",
-					Value:    "total",
-				},
-			},
+	// The probe is defined by LITMUS_PROBE_DEFINITION or by the probe ENVs
+	definition, err := probe.FromExperimentDetails(details)
+	if err != nil {
+		return err
+	}
+	log.Printf("Creating a new %s with name: %s", definition.Type, definition.Name)
+
+	probeReq, err := definition.Request()
+	if err != nil {
+		return err
+	}
+
+	// The SDK only supports the HTTP and CMD probes, so the probe is created through the GraphQL API
+	client, err := chaoscenter.NewClient(details, sdkClient)
+	if err != nil {
+		return err
+	}
+	client.ProjectID = litmusProjectID
+
+	// The probe is keyed on its name, so that an existing probe is updated rather than failing the run
+	exists, err := client.ProbeExists(definition.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check if probe %s exists: %v", definition.Name, err)
+	}
+	if exists {
+		if err := client.UpdateProbe(*probeReq); err != nil {
+			log.Printf("Failed to update probe: %v", err)
+			return err
 		}
-	default:
-		return fmt.Errorf("unsupported probe type: %s", details.ProbeType)
+		log.Printf("Successfully updated existing probe: %s", definition.Name)
+		details.CreatedProbeID = definition.Name
+		return nil
 	}
 
-	// Create probe
-	createdProbe, err := sdkClient.Probes().Create(probeReq, litmusProjectID)
+	createdProbe, err := client.AddProbe(*probeReq)
 	if err != nil {
 		log.Printf("Failed to create probe: %v", err)
 		return err
@@ -2302,5 +1036,43 @@ func CreateProbe(details *types.ExperimentDetails, sdkClient sdk.Client, litmusP
 	log.Printf("Successfully created probe: %s", createdProbe.Name)
 
 	details.CreatedProbeID = createdProbe.Name
+	if !pkg.ContainsString(details.CreatedProbes, createdProbe.Name) {
+		details.CreatedProbes = append(details.CreatedProbes, createdProbe.Name)
+	}
+	return nil
+}
+
+// DeleteCreatedProbes deletes the probes created during the run, if LITMUS_DELETE_PROBES is set to true.
+// The probes which existed before the run are left in place.
+func DeleteCreatedProbes(details *types.ExperimentDetails, sdkClient sdk.Client) error {
+	if !details.DeleteProbes {
+		log.Println("Skipping probe cleanup as LITMUS_DELETE_PROBES is not set to true")
+		return nil
+	}
+	if len(details.CreatedProbes) == 0 {
+		log.Println("No probes were created during the run, skipping probe cleanup")
+		return nil
+	}
+
+	client, err := chaoscenter.NewClient(details, sdkClient)
+	if err != nil {
+		return err
+	}
+
+	// Every probe is attempted, so that one failure doesn't leave the others behind
+	var failed []string
+	for _, probeName := range details.CreatedProbes {
+		if err := client.DeleteProbe(probeName); err != nil {
+			log.Printf("Failed to delete probe %s: %v", probeName, err)
+			failed = append(failed, probeName)
+			continue
+		}
+		log.Printf("Successfully deleted probe: %s", probeName)
+	}
+	details.CreatedProbes = failed
+
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to delete probes: %s", strings.Join(failed, ", "))
+	}
 	return nil
 }
@@ -0,0 +1,75 @@
+package workflow
+
+import "testing"
+
+const httpLatencyExperiment = `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosExperiment
+metadata:
+  name: pod-http-latency
+spec:
+  definition:
+    image: litmuschaos/go-runner:latest
+`
+
+func TestGetDefaultExperimentConfigLibImage(t *testing.T) {
+	tests := []struct {
+		name   string
+		engine string
+		want   string
+	}{
+		{
+			name: "declared by the hub fault",
+			engine: `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+spec:
+  experiments:
+  - name: pod-http-latency
+    spec:
+      components:
+        env:
+        - name: LIB_IMAGE
+          value: registry.example.com/litmuschaos/go-runner:3.17.0
+`,
+			want: "registry.example.com/litmuschaos/go-runner:3.17.0",
+		},
+		{
+			name: "placeholder in the hub fault",
+			engine: `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+spec:
+  experiments:
+  - name: pod-http-latency
+    spec:
+      components:
+        env:
+        - name: LIB_IMAGE
+          value: __LIB_IMAGE_VALUE__
+`,
+			want: defaultLibImage,
+		},
+		{
+			name: "missing from the hub fault",
+			engine: `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+spec:
+  experiments:
+  - name: pod-http-latency
+    spec:
+      components:
+        env:
+        - name: LATENCY
+          value: "2000"
+`,
+			want: defaultLibImage,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeHubFault(t, "pod-http-latency", httpLatencyExperiment, tt.engine)
+
+			if got := GetDefaultExperimentConfig(PodHTTPLatency).LibImage; got != tt.want {
+				t.Errorf("expected LIB_IMAGE %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
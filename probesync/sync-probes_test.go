@@ -0,0 +1,56 @@
+package probesync
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/chaoscenter"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/probe"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/klog"
+)
+
+func TestSyncProbes(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BDD test")
+}
+
+// BDD for syncing the probe definitions of a directory with the ChaosCenter project
+var _ = Describe("BDD of syncing the probe definitions", func() {
+
+	Context("Check for the probes of the project", func() {
+
+		It("Should converge the probes of the project to the definitions", func() {
+
+			experimentsDetails := types.ExperimentDetails{}
+			probeDir := environment.Getenv("LITMUS_PROBE_SYNC_DIR", "probes")
+			dryRun, _ := strconv.ParseBool(environment.Getenv("LITMUS_PROBE_SYNC_DRY_RUN", "false"))
+
+			//Fetching all the default ENV
+			By("[PreSync]: Fetching all default ENVs")
+			environment.GetENV(&experimentsDetails, "probe-sync", "")
+
+			// Initialize SDK client
+			By("[PreSync]: Initializing SDK client")
+			sdkClient, err := environment.GenerateClientSetFromSDK()
+			Expect(err).To(BeNil(), "Unable to generate Litmus SDK client, due to {%v}", err)
+
+			client, err := chaoscenter.NewClient(&experimentsDetails, sdkClient)
+			Expect(err).To(BeNil(), "Unable to create the ChaosCenter client, due to {%v}", err)
+
+			// Compute the plan and apply it, unless it is a dry run
+			By("[Sync]: Syncing the probe definitions")
+			plan, err := probe.Sync(probeDir, client, dryRun)
+			if dryRun {
+				klog.Infof("[Sync]: Dry run, the probes of project %s would be synced with %s as follows:\n%v", experimentsDetails.LitmusProjectID, probeDir, plan)
+			} else {
+				klog.Infof("[Sync]: Synced the probes of project %s with %s:\n%v", experimentsDetails.LitmusProjectID, probeDir, plan)
+			}
+			Expect(err).To(BeNil(), "Failed to sync the probes, due to {%v}", err)
+		})
+	})
+})
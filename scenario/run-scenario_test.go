@@ -0,0 +1,127 @@
+package scenario
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/chaos-ci-lib/pkg/environment"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/runner"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/scenario"
+	"github.com/litmuschaos/chaos-ci-lib/pkg/workflow"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/klog"
+)
+
+func TestRunScenario(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BDD test")
+}
+
+// BDD for running the experiments of a chaos-ci.yaml scenario file
+var _ = Describe("BDD of running the chaos-ci scenario", func() {
+
+	scenarioFile := environment.Getenv("SCENARIO_FILE", "chaos-ci.yaml")
+	file, loadErr := scenario.Load(scenarioFile)
+	if loadErr != nil {
+		It("Should load the scenario file", func() {
+			Expect(loadErr).To(BeNil(), "Failed to load the scenario file, due to {%v}", loadErr)
+		})
+		return
+	}
+
+	// The experiments run in parallel as a batch, with a verdict per experiment
+	if file.MaxInFlight > 1 {
+		Context("Check for the scenario experiments via SDK", func() {
+
+			It("Should run the scenario experiments in parallel via SDK", func(specCtx SpecContext) {
+
+				//Fetching all the default ENV and the overrides of the scenario
+				By("[PreChaos]: Fetching all default ENVs and the scenario overrides")
+				var jobs []runner.Job
+				for _, experiment := range file.Experiments {
+					config := experiment.ExperimentConfig()
+					jobs = append(jobs, runner.Job{
+						ExperimentType: experiment.ExperimentType(),
+						Details:        experiment.ExperimentDetails(),
+						Options: runner.Options{
+							Name:            experiment.Name,
+							Config:          &config,
+							ExpectedPhase:   experiment.ExpectedPhase(),
+							PostChaosChecks: postChaosChecks(experiment),
+						},
+					})
+				}
+
+				// Setup, run, verdict and cleanup of the experiments, a cancelled job stops the chaos before the cleanup
+				ctx, stop := runner.NotifyContext(specCtx)
+				defer stop()
+
+				By(fmt.Sprintf("[Chaos]: Running %d experiments, at most %d at the same time", len(jobs), file.MaxInFlight))
+				results, errs := runner.RunAll(ctx, jobs, runner.BatchOptions{MaxInFlight: file.MaxInFlight, InfraIDs: file.Infras})
+				var failures []string
+				for i, result := range results {
+					runner.ExitIfCancelled(result)
+					switch name := file.Experiments[i].Name; {
+					case errs[i] != nil:
+						failures = append(failures, fmt.Sprintf("%s: failed to run, due to {%v}", name, errs[i]))
+					case result.Phase != result.ExpectedPhase:
+						failures = append(failures, fmt.Sprintf("%s: Experiment Run phase should be %s, but got %s: %s", name, result.ExpectedPhase, result.Phase, result.Reason()))
+					case len(result.UnmetCriteria) != 0:
+						failures = append(failures, fmt.Sprintf("%s: Pass criteria of the Experiment Run not met: %s", name, result.Reason()))
+					}
+				}
+				Expect(failures).To(BeEmpty(), "Failed experiments of the scenario:\n%s", strings.Join(failures, "\n"))
+			}, GracePeriod(runner.CancelGracePeriod))
+		})
+		return
+	}
+
+	for i, experiment := range file.Experiments {
+
+		// The experiments run on the listed infrastructures in turn, or set up their own one
+		infraID := ""
+		if len(file.Infras) != 0 {
+			infraID = file.Infras[i%len(file.Infras)]
+		}
+
+		Context(fmt.Sprintf("Check for %s experiment via SDK", experiment.Name), func() {
+
+			It(fmt.Sprintf("Should run the %s experiment via SDK", experiment.Name), func(specCtx SpecContext) {
+
+				//Fetching all the default ENV and the overrides of the scenario
+				By("[PreChaos]: Fetching all default ENVs and the scenario overrides")
+				experimentsDetails := experiment.ExperimentDetails()
+				klog.Infof("[PreReq]: Getting the ENVs for the %v experiment", experiment.Name)
+
+				// Setup, run, verdict and cleanup of the experiment, a cancelled job stops the chaos before the cleanup
+				ctx, stop := runner.NotifyContext(specCtx)
+				defer stop()
+
+				By(fmt.Sprintf("[Chaos]: Running the %s experiment", experiment.Name))
+				config := experiment.ExperimentConfig()
+				result, err := runner.Run(ctx, experiment.ExperimentType(), &experimentsDetails, runner.Options{
+					Name:            experiment.Name,
+					Config:          &config,
+					InfraID:         infraID,
+					ExpectedPhase:   experiment.ExpectedPhase(),
+					PostChaosChecks: postChaosChecks(experiment),
+				})
+				runner.ExitIfCancelled(result)
+				Expect(err).To(BeNil(), "Failed to run the %s experiment, due to {%v}", experiment.Name, err)
+				Expect(result.Phase).To(Equal(result.ExpectedPhase), "Experiment Run phase should be %s, but got %s: %s", result.ExpectedPhase, result.Phase, result.Reason())
+				Expect(result.UnmetCriteria).To(BeEmpty(), "Pass criteria of the Experiment Run not met: %s", result.Reason())
+			}, GracePeriod(runner.CancelGracePeriod))
+		})
+	}
+})
+
+// postChaosChecks returns the checks run once the experiment passed, the node faults wait for their node to recover
+func postChaosChecks(experiment scenario.Experiment) []runner.Check {
+	if workflow.TargetsNode(experiment.ExperimentType()) {
+		return []runner.Check{runner.NodeRecoveryCheck}
+	}
+	return nil
+}